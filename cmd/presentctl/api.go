@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"interactive-presentation/src/client"
+	"interactive-presentation/src/models"
+)
+
+const (
+	defaultAPIURL = "http://localhost:8080"
+)
+
+// The commands in this file call the HTTP API of a running service.
+
+// apiFlags returns a flag set with the -api and -token flags shared by every
+// command talking to the HTTP API. The token defaults to PRESENTER_TOKEN.
+func apiFlags(name string) (*flag.FlagSet, *string, *string) {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	apiURL := flags.String("api", defaultAPIURL, "base URL of the service")
+	token := flags.String("token", os.Getenv("PRESENTER_TOKEN"), "presenter token of the presentation, defaults to $PRESENTER_TOKEN")
+	return flags, apiURL, token
+}
+
+func runNext(args []string) error {
+	flags, apiURL, token := apiFlags("next")
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		return errors.New("usage: presentctl next [-api URL] [-token TOKEN] PRESENTATION_ID")
+	}
+
+	presentationID, err := uuid.Parse(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid presentation ID: %v", err)
+	}
+
+	poll, err := client.New(*apiURL, *token).NextPoll(presentationID)
+	if err != nil {
+		return err
+	}
+	if poll.Question == "" {
+		fmt.Println("No more polls")
+		return nil
+	}
+	fmt.Println(poll.Question)
+	for _, option := range poll.Options {
+		fmt.Printf("  %s: %s\n", option.Key, option.Value)
+	}
+	return nil
+}
+
+func runExport(args []string) error {
+	flags, apiURL, token := apiFlags("export")
+	format := flags.String("format", "csv", "export format, one of csv, jsonl or xlsx")
+	output := flags.String("o", "", "file to write the export to, defaults to standard output")
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		return errors.New("usage: presentctl export [-api URL] [-token TOKEN] [-format csv|jsonl|xlsx] [-o FILE] PRESENTATION_ID")
+	}
+
+	presentationID, err := uuid.Parse(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid presentation ID: %v", err)
+	}
+
+	export, err := client.New(*apiURL, *token).Export(presentationID, *format)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = export.Close()
+	}()
+
+	var out io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("error creating %s: %v", *output, err)
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		out = file
+	}
+
+	if _, err = io.Copy(out, export); err != nil {
+		return fmt.Errorf("error writing export: %v", err)
+	}
+	return nil
+}
+
+// demoPresentation is the presentation created by the seed command.
+var demoPresentation = models.Presentation{
+	Polls: []models.Poll{
+		{
+			Question: "What's your favorite pet?",
+			Options: []models.Option{
+				{Key: "A", Value: "Dog"},
+				{Key: "B", Value: "Cat"},
+				{Key: "C", Value: "Crocodile"},
+			},
+		},
+		{
+			Question: "Which of the countries would you like to visit the most?",
+			Options: []models.Option{
+				{Key: "A", Value: "Argentina"},
+				{Key: "B", Value: "Austria"},
+				{Key: "C", Value: "Australia"},
+			},
+		},
+	},
+}
+
+func runSeed(args []string) error {
+	flags, apiURL, _ := apiFlags("seed")
+	_ = flags.Parse(args)
+
+	body, err := client.New(*apiURL, "").CreatePresentation(demoPresentation)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(strings.TrimSpace(string(body)))
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+
+	"interactive-presentation/src/models"
+	"interactive-presentation/src/storage"
+)
+
+// The commands in this file work directly against the database configured
+// with DATABASE_URL.
+
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	_ = flags.Parse(args)
+
+	version, err := storage.Migrate()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Schema is at version %d\n", version)
+	return nil
+}
+
+func runList(args []string) error {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	_ = flags.Parse(args)
+
+	presentations, err := storage.ListPresentations(context.Background())
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "PRESENTATION ID\tCURRENT POLL\tPOLLS\tJOIN CODE")
+	for _, presentation := range presentations {
+		_, _ = fmt.Fprintf(writer, "%s\t%d\t%d\t%s\n", presentation.PresentationID, presentation.CurrentPollIndex+1, presentation.Polls, presentation.JoinCode)
+	}
+	return writer.Flush()
+}
+
+func runDelete(args []string) error {
+	flags := flag.NewFlagSet("delete", flag.ExitOnError)
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		return errors.New("usage: presentctl delete PRESENTATION_ID")
+	}
+
+	presentationID, err := uuid.Parse(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid presentation ID: %v", err)
+	}
+
+	deleted, err := storage.DeletePresentation(context.Background(), presentationID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("no presentation found with ID %s", presentationID)
+	}
+	fmt.Printf("Deleted presentation %s\n", presentationID)
+	return nil
+}
+
+// runTail prints votes of a presentation as they are recorded until it is
+// interrupted, checking for votes recorded after the last one printed.
+func runTail(args []string) error {
+	flags := flag.NewFlagSet("tail", flag.ExitOnError)
+	interval := flags.Duration("interval", time.Second, "time between checks for new votes")
+	all := flags.Bool("all", false, "print the votes recorded so far before following new ones")
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		return errors.New("usage: presentctl tail [-interval 1s] [-all] PRESENTATION_ID")
+	}
+
+	presentationID, err := uuid.Parse(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid presentation ID: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var lastVoteID int64
+	print := *all
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	for {
+		err = storage.ForEachVoteAfter(ctx, presentationID, lastVoteID, func(vote models.VoteExport) error {
+			lastVoteID = vote.VoteID
+			if print {
+				fmt.Printf("%s poll %d %q: %s (%s) from %s\n", vote.CreatedAt.Local().Format(time.TimeOnly), vote.PollIndex+1, vote.Question, vote.Key, vote.Value, vote.ClientID)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		print = true
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"interactive-presentation/src/client"
+	"interactive-presentation/src/importer"
+)
+
+// runImport validates a YAML, JSON or Markdown file locally, printing every
+// problem as FILE:LINE: MESSAGE, and creates the presentation through the
+// import endpoint of the service.
+func runImport(args []string) error {
+	flags, apiURL, _ := apiFlags("import")
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		return errors.New("usage: presentctl import [-api URL] FILE")
+	}
+	filename := flags.Arg(0)
+
+	format, err := importer.FormatFromFilename(filename)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", filename, err)
+	}
+
+	_, err = importer.Parse(format, data)
+	var importErrors importer.Errors
+	if errors.As(err, &importErrors) {
+		var lines []string
+		for _, lineError := range importErrors {
+			lines = append(lines, fmt.Sprintf("%s:%d: %s", filename, lineError.Line, lineError.Message))
+		}
+		return errors.New(strings.Join(lines, "\n"))
+	}
+	if err != nil {
+		return err
+	}
+
+	body, err := client.New(*apiURL, "").ImportPresentation(format, data)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(strings.TrimSpace(string(body)))
+	return nil
+}
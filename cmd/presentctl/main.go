@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+type command struct {
+	usage       string
+	description string
+	run         func(args []string) error
+}
+
+var commands = map[string]command{
+	"migrate": {usage: "migrate", description: "apply pending schema migrations", run: runMigrate},
+	"list":    {usage: "list", description: "list presentations", run: runList},
+	"delete":  {usage: "delete PRESENTATION_ID", description: "delete a presentation with its polls and votes", run: runDelete},
+	"tail":    {usage: "tail [-interval 1s] [-all] PRESENTATION_ID", description: "print votes as they are recorded", run: runTail},
+	"import":  {usage: "import [-api URL] FILE", description: "create a presentation from a YAML, JSON or Markdown file", run: runImport},
+	"seed":    {usage: "seed [-api URL]", description: "create a demo presentation", run: runSeed},
+	"next":    {usage: "next [-api URL] [-token TOKEN] PRESENTATION_ID", description: "advance to the next poll", run: runNext},
+	"export":  {usage: "export [-api URL] [-token TOKEN] [-format csv|jsonl|xlsx] [-o FILE] PRESENTATION_ID", description: "export all votes", run: runExport},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	cmd, found := commands[os.Args[1]]
+	if !found {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n", os.Args[1])
+		printUsage()
+		os.Exit(2)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "usage: presentctl COMMAND [ARGS]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "migrate, list, delete and tail work directly against the database in DATABASE_URL,")
+	fmt.Fprintln(os.Stderr, "the other commands call the HTTP API of a running service.")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n      %s\n", commands[name].usage, commands[name].description)
+	}
+}
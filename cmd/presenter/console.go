@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"golang.org/x/term"
+
+	"interactive-presentation/src/client"
+	"interactive-presentation/src/models"
+)
+
+type console struct {
+	client         *client.Client
+	presentationID uuid.UUID
+
+	poll    models.PollV2
+	message string
+}
+
+func (c *console) handle(k key) {
+	var err error
+	switch k {
+	case keyNext:
+		if c.poll.Index+1 >= c.poll.Polls {
+			c.message = "This is the last poll"
+			return
+		}
+		_, err = c.client.NextPoll(c.presentationID)
+	case keyPrevious:
+		if c.poll.Index == 0 {
+			c.message = "This is the first poll"
+			return
+		}
+		_, err = c.client.ShowPoll(c.presentationID, c.poll.Index-1)
+	case keyOpen:
+		err = c.client.SetCurrentPollStatus(c.presentationID, models.PollOpen)
+	case keyClose:
+		err = c.client.SetCurrentPollStatus(c.presentationID, models.PollClosed)
+	}
+	if err != nil {
+		c.message = err.Error()
+		return
+	}
+	c.message = ""
+	c.refresh()
+}
+
+// refresh reloads the current poll with its status and votes. Errors are
+// shown in the message line so a flaky connection does not end the session.
+func (c *console) refresh() {
+	poll, err := c.client.CurrentPoll(c.presentationID)
+	if err != nil {
+		c.message = err.Error()
+		return
+	}
+	c.poll = poll
+}
+
+func (c *console) draw() {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width < 40 {
+		width = 80
+	}
+
+	var screen strings.Builder
+	screen.WriteString("\x1b[H\x1b[2J")
+	line := func(format string, args ...interface{}) {
+		screen.WriteString(fmt.Sprintf(format, args...) + "\r\n")
+	}
+
+	line("\x1b[1mPresentation %s\x1b[0m", c.presentationID)
+	if c.poll.Question == "" {
+		line("")
+		line("No poll is being shown.")
+	} else {
+		status := "\x1b[32mopen\x1b[0m"
+		if c.poll.Status == models.PollClosed {
+			status = "\x1b[31mclosed\x1b[0m"
+		}
+		line("Poll %d of %d, %s, %d votes", c.poll.Index+1, c.poll.Polls, status, c.poll.Votes)
+		line("")
+		line("\x1b[1m%s\x1b[0m", c.poll.Question)
+		line("")
+
+		labelWidth := 0
+		for _, option := range c.poll.Options {
+			labelWidth = max(labelWidth, utf8.RuneCountInString(option.Value))
+		}
+		labelWidth = min(labelWidth, width/3)
+		barWidth := max(10, width-labelWidth-24)
+
+		for _, option := range c.poll.Options {
+			filled := 0
+			if c.poll.Votes > 0 {
+				filled = option.Votes * barWidth / c.poll.Votes
+			}
+			line(" %-3s %-*s %s%s %4d %5.1f%%", option.Key, labelWidth, truncate(option.Value, labelWidth),
+				strings.Repeat("█", filled), strings.Repeat("░", barWidth-filled), option.Votes, option.Percentage)
+		}
+	}
+
+	line("")
+	line("\x1b[2m[n/→] next  [p/←] previous  [o] open  [c] close  [r] refresh  [q] quit\x1b[0m")
+	if c.message != "" {
+		line("\x1b[33m%s\x1b[0m", c.message)
+	}
+
+	fmt.Print(screen.String())
+}
+
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) > length {
+		return string(runes[:max(0, length-1)]) + "…"
+	}
+	return text
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"io"
+)
+
+type key int
+
+const (
+	keyNone key = iota
+	keyQuit
+	keyNext
+	keyPrevious
+	keyOpen
+	keyClose
+	keyRefresh
+)
+
+// readKeys translates the raw bytes typed into the terminal into keys until
+// the input is closed.
+func readKeys(input io.Reader) <-chan key {
+	keys := make(chan key)
+	go func() {
+		defer close(keys)
+		buffer := make([]byte, 16)
+		for {
+			n, err := input.Read(buffer)
+			if err != nil {
+				return
+			}
+			if k := parseKey(buffer[:n]); k != keyNone {
+				keys <- k
+			}
+		}
+	}()
+	return keys
+}
+
+func parseKey(input []byte) key {
+	switch string(input) {
+	case "\x1b[C", "\x1bOC":
+		return keyNext
+	case "\x1b[D", "\x1bOD":
+		return keyPrevious
+	}
+	if len(input) != 1 {
+		return keyNone
+	}
+
+	switch input[0] {
+	case 'q', 'Q', 3, 4:
+		return keyQuit
+	case 'n', 'N', ' ':
+		return keyNext
+	case 'p', 'P':
+		return keyPrevious
+	case 'o', 'O':
+		return keyOpen
+	case 'c', 'C':
+		return keyClose
+	case 'r', 'R':
+		return keyRefresh
+	default:
+		return keyNone
+	}
+}
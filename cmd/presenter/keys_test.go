@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKey(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		inputs := map[string]key{
+			"q":      keyQuit,
+			"\x03":   keyQuit,
+			"n":      keyNext,
+			" ":      keyNext,
+			"\x1b[C": keyNext,
+			"\x1bOC": keyNext,
+			"P":      keyPrevious,
+			"\x1b[D": keyPrevious,
+			"o":      keyOpen,
+			"c":      keyClose,
+			"r":      keyRefresh,
+		}
+
+		for input, expected := range inputs {
+			// Act
+			k := parseKey([]byte(input))
+
+			// Assert
+			assert.Equal(t, expected, k, "input %q", input)
+		}
+	})
+
+	t.Run("Unknown Input", func(t *testing.T) {
+		// Arrange
+		inputs := []string{"x", "nn", "\x1b[A"}
+
+		for _, input := range inputs {
+			// Act
+			k := parseKey([]byte(input))
+
+			// Assert
+			assert.Equal(t, keyNone, k, "input %q", input)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+// Command presenter is an interactive terminal console for running a
+// presentation: it shows the current poll with live results and moves
+// between polls through the same endpoints as the presenter API.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/term"
+
+	"interactive-presentation/src/client"
+)
+
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	leaveScreen = "\x1b[?25h\x1b[?1049l"
+)
+
+func main() {
+	apiURL := flag.String("api", "http://localhost:8080", "base URL of the service")
+	token := flag.String("token", os.Getenv("PRESENTER_TOKEN"), "presenter token of the presentation, defaults to $PRESENTER_TOKEN")
+	interval := flag.Duration("interval", time.Second, "time between refreshes of the results")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: presenter [-api URL] [-token TOKEN] [-interval 1s] PRESENTATION_ID")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*apiURL, *token, *interval, flag.Arg(0)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(apiURL string, token string, interval time.Duration, presentationArg string) error {
+	presentationID, err := uuid.Parse(presentationArg)
+	if err != nil {
+		return fmt.Errorf("invalid presentation ID: %v", err)
+	}
+	if token == "" {
+		return errors.New("a presenter token is required, pass -token or set PRESENTER_TOKEN")
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return errors.New("presenter needs an interactive terminal")
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("error switching the terminal to raw mode: %v", err)
+	}
+	defer func() {
+		_ = term.Restore(fd, state)
+	}()
+
+	fmt.Print(enterScreen)
+	defer fmt.Print(leaveScreen)
+
+	c := &console{client: client.New(apiURL, token), presentationID: presentationID}
+	keys := readKeys(os.Stdin)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	c.refresh()
+	c.draw()
+	for {
+		select {
+		case k, ok := <-keys:
+			if !ok || k == keyQuit {
+				return nil
+			}
+			c.handle(k)
+		case <-ticker.C:
+			c.refresh()
+		}
+		c.draw()
+	}
+}
@@ -1,102 +1,102 @@
 package main
 
 import (
-	"database/sql"
+	"context"
 	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
-
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
-	_ "github.com/lib/pq"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"interactive-presentation/src/config"
 	"interactive-presentation/src/handlers"
+	"interactive-presentation/src/logging"
+	"interactive-presentation/src/metrics"
+	"interactive-presentation/src/storage"
+	"interactive-presentation/src/tracing"
 )
 
-const (
-	Presentation = "presentation"
-	Poll         = "poll"
-	Option       = "option"
-	Vote         = "vote"
-)
-
-func pingHandler(w http.ResponseWriter, _ *http.Request) {
-	_, err := w.Write([]byte("Service is up and running"))
-	if err != nil {
-		log.Println("error writing data for pingHandler: ", err)
-	}
+func fatal(message string, err error) {
+	slog.Error(message, "error", err)
+	os.Exit(1)
 }
 
 func main() {
-	configuration, err := config.New()
+	configuration, err := config.Load(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
-		log.Fatal("error creating and initializing a new configuration object: ", err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
 	}
+	slog.SetDefault(logging.New(os.Stdout, configuration.LogLevel))
+	storage.Configure(configuration)
+	handlers.Configure(configuration)
 
-	db, err := newDB(configuration)
+	shutdownTracing, err := tracing.Setup(context.Background(), configuration.TracesExporter)
 	if err != nil {
-		log.Fatal("error connecting to database: ", err)
+		fatal("error setting up tracing", err)
 	}
-	defer func(db *sql.DB) {
-		err = db.Close()
-		if err != nil {
-			log.Println("error closing database connection: ", err)
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("error shutting down tracing", "error", err)
 		}
-	}(db)
-
-	r := chi.NewRouter()
-	r.Use(middleware.Logger)
-
-	r.Get("/ping", pingHandler)
-
-	r.Post("/presentations", handlers.CreatePresentation)
-
-	r.Get("/presentations/{presentation_id}/polls/current", handlers.GetCurrentPoll)
-	r.Put("/presentations/{presentation_id}/polls/current", handlers.PutCurrentPoll)
-
-	r.Post("/presentations/{presentation_id}/polls/current/votes", handlers.PostPollVote)
-	r.Get("/presentations/{presentation_id}/polls/{poll_id}/votes", handlers.GetPollVotes)
+	}()
 
-	log.Println("Starting server on :8080...")
-	err = http.ListenAndServe(":8080", r)
+	version, err := storage.Migrate()
 	if err != nil {
-		log.Fatal("error listening on the TCP network address and calling Serve with handler", err)
+		fatal("error migrating database", err)
 	}
-}
+	slog.Info("connected to database", "schema_version", version)
 
-func newDB(config *config.Config) (*sql.DB, error) {
-	db, err := sql.Open("postgres", config.DatabaseURL)
+	db, err := storage.DB()
 	if err != nil {
-		return nil, errors.New(fmt.Sprintf("error opening a database connection: %v", err))
+		fatal("error opening database connection pool", err)
 	}
-
-	statement := "CREATE TABLE IF NOT EXISTS " + Presentation + " (presentation_id uuid PRIMARY KEY, current_poll_index integer);"
-	_, err = db.Exec(statement)
-	if err != nil {
-		return nil, errors.New(fmt.Sprintf("error creating %s table: %v", Presentation, err))
+	if err = metrics.RegisterDB(db); err != nil {
+		fatal("error registering database metrics", err)
 	}
 
-	statement = "CREATE TABLE IF NOT EXISTS " + Poll + " (poll_id uuid PRIMARY KEY, question VARCHAR(255), presentation_id uuid, index integer);"
-	_, err = db.Exec(statement)
-	if err != nil {
-		return nil, errors.New(fmt.Sprintf("error creating %s table: %v", Poll, err))
+	server := &http.Server{
+		Addr:              configuration.ListenAddress,
+		Handler:           newRouter(configuration),
+		ReadTimeout:       configuration.ReadTimeout,
+		ReadHeaderTimeout: configuration.ReadTimeout,
+		WriteTimeout:      configuration.WriteTimeout,
+		IdleTimeout:       configuration.IdleTimeout,
 	}
+	server.RegisterOnShutdown(handlers.CloseStreams)
 
-	statement = "CREATE TABLE IF NOT EXISTS " + Option + " (key VARCHAR(255), value VARCHAR(255), poll_id uuid, index integer);"
-	_, err = db.Exec(statement)
-	if err != nil {
-		return nil, errors.New(fmt.Sprintf("error creating %s table: %v", Option, err))
-	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	statement = "CREATE TABLE IF NOT EXISTS " + Vote + " (key VARCHAR(255), client_id VARCHAR(255), poll_id uuid);"
-	_, err = db.Exec(statement)
-	if err != nil {
-		return nil, errors.New(fmt.Sprintf("error creating %s table: %v", Vote, err))
-	}
+	serverErrors := make(chan error, 1)
+	go func() {
+		slog.Info("starting server", "address", server.Addr)
+		serverErrors <- server.ListenAndServe()
+	}()
 
-	log.Println("Connected to database")
+	select {
+	case err = <-serverErrors:
+		fatal("error listening on the TCP network address and calling Serve with handler", err)
+	case <-ctx.Done():
+	}
+	stop()
+
+	slog.Info("shutting down server", "timeout", configuration.ShutdownTimeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), configuration.ShutdownTimeout)
+	defer cancel()
+	if err = server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("error draining connections, closing them", "error", err)
+		_ = server.Close()
+	}
 
-	return db, nil
+	if err = storage.Close(); err != nil {
+		slog.Error("error closing database connection pool", "error", err)
+	}
+	slog.Info("server stopped")
 }
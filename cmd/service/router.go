@@ -0,0 +1,141 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/cors"
+
+	"interactive-presentation/src/config"
+	"interactive-presentation/src/handlers"
+	"interactive-presentation/src/logging"
+	"interactive-presentation/src/metrics"
+	"interactive-presentation/src/openapi"
+	"interactive-presentation/src/tracing"
+	"interactive-presentation/src/web"
+)
+
+func pingHandler(w http.ResponseWriter, _ *http.Request) {
+	_, err := w.Write([]byte("Service is up and running"))
+	if err != nil {
+		slog.Error("error writing data for pingHandler", "error", err)
+	}
+}
+
+// apiHandlers holds the handlers that differ between versions of the API.
+type apiHandlers struct {
+	getCurrentPoll http.HandlerFunc
+	putCurrentPoll http.HandlerFunc
+	getPollResults http.HandlerFunc
+}
+
+var (
+	v1 = apiHandlers{
+		getCurrentPoll: handlers.GetCurrentPoll,
+		putCurrentPoll: handlers.PutCurrentPoll,
+		getPollResults: handlers.GetPollResults,
+	}
+	v2 = apiHandlers{
+		getCurrentPoll: handlers.GetCurrentPollV2,
+		putCurrentPoll: handlers.PutCurrentPollV2,
+		getPollResults: handlers.GetPollResultsV2,
+	}
+)
+
+// newRouter registers the routes of the features enabled in configuration.
+// Routes added here belong in openapi.Routes as well.
+func newRouter(configuration *config.Config) chi.Router {
+	r := chi.NewRouter()
+	r.Use(logging.RequestID)
+	r.Use(tracing.Middleware)
+	r.Use(logging.Requests)
+	r.Use(metrics.Middleware)
+	if len(configuration.CORSAllowedOrigins) > 0 {
+		r.Use(cors.Handler(cors.Options{
+			AllowedOrigins: configuration.CORSAllowedOrigins,
+			AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut},
+			AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-Presenter-Token", "Idempotency-Key", "If-None-Match", logging.RequestIDHeader},
+			ExposedHeaders: []string{"Retry-After", "Deprecation", "Link", "Idempotent-Replayed", "ETag", logging.RequestIDHeader},
+			MaxAge:         300,
+		}))
+	}
+
+	r.Get("/ping", pingHandler)
+	r.Get("/healthz", handlers.GetHealthz)
+	r.Get("/readyz", handlers.GetReadyz)
+	r.Get("/openapi.json", openapi.Handler)
+	r.Get("/docs", openapi.Docs)
+	if configuration.Metrics {
+		r.Method(http.MethodGet, "/metrics", metrics.Handler())
+	}
+
+	if configuration.WebApp {
+		r.Get("/", web.Page("index.html"))
+		r.Get("/present", web.Page("present.html"))
+		r.Method(http.MethodGet, "/assets/*", http.StripPrefix("/assets/", web.Assets()))
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Use(handlers.DeprecateV1)
+		apiRoutes(r, configuration, v1)
+	})
+	r.Route("/v1", func(r chi.Router) {
+		r.Use(handlers.DeprecateV1)
+		apiRoutes(r, configuration, v1)
+	})
+	r.Route("/v2", func(r chi.Router) {
+		apiRoutes(r, configuration, v2)
+	})
+
+	return r
+}
+
+// apiRoutes registers the API routes of the features enabled in configuration
+// with the handlers of one version. POST routes honor Idempotency-Key headers.
+func apiRoutes(r chi.Router, configuration *config.Config, api apiHandlers) {
+	r.With(handlers.Idempotent).Post("/presentations", handlers.CreatePresentation)
+	r.With(handlers.Idempotent).Post("/presentations/import", handlers.ImportPresentation)
+
+	r.Get("/join/{code}", handlers.ResolveJoinCode)
+	r.Get("/presentations/{presentation_id}/join-code", handlers.GetJoinCode)
+	r.Get("/presentations/{presentation_id}/qr.png", handlers.GetQRCodePNG)
+	r.Get("/presentations/{presentation_id}/qr.svg", handlers.GetQRCodeSVG)
+
+	r.Get("/presentations/{presentation_id}/polls/current", api.getCurrentPoll)
+	r.Get("/presentations/{presentation_id}/polls/current/status", handlers.GetCurrentPollStatus)
+	r.With(handlers.Idempotent).Post("/presentations/{presentation_id}/polls/current/votes", handlers.PostPollVote)
+	r.Get("/presentations/{presentation_id}/polls/{poll_id}/results", api.getPollResults)
+	r.Get("/presentations/{presentation_id}/polls/{poll_id}/chart.svg", handlers.GetPollChartSVG)
+	r.Get("/presentations/{presentation_id}/polls/{poll_id}/chart.png", handlers.GetPollChartPNG)
+
+	if configuration.Submissions {
+		r.With(handlers.Idempotent).Post("/presentations/{presentation_id}/polls/current/submissions", handlers.PostSubmission)
+		r.Get("/presentations/{presentation_id}/polls/{poll_id}/submissions", handlers.GetApprovedSubmissions)
+	}
+
+	if configuration.Reactions {
+		r.With(handlers.Idempotent).Post("/presentations/{presentation_id}/reactions", handlers.PostReaction)
+		r.Get("/presentations/{presentation_id}/reactions/stream", handlers.StreamReactions)
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Use(handlers.RequirePresenter)
+
+		r.With(handlers.Idempotent).Post("/presentations/{presentation_id}/join-code", handlers.PostJoinCode)
+
+		r.Put("/presentations/{presentation_id}/polls/current", api.putCurrentPoll)
+		r.Put("/presentations/{presentation_id}/polls/current/status", handlers.PutCurrentPollStatus)
+		r.Get("/presentations/{presentation_id}/polls/{poll_id}/votes", handlers.GetPollVotes)
+		r.Get("/presentations/{presentation_id}/export", handlers.ExportPresentation)
+		r.Get("/presentations/{presentation_id}/report", handlers.GetReport)
+
+		if configuration.Submissions {
+			r.Get("/presentations/{presentation_id}/submissions", handlers.GetSubmissions)
+			r.Put("/presentations/{presentation_id}/submissions/{submission_id}", handlers.PutSubmissionStatus)
+
+			r.Get("/presentations/{presentation_id}/blocklist", handlers.GetBlocklist)
+			r.Put("/presentations/{presentation_id}/blocklist", handlers.PutBlocklist)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+
+	"interactive-presentation/src/config"
+	"interactive-presentation/src/openapi"
+)
+
+func TestRoutesMatchOpenAPI(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		router := newRouter(config.Default())
+		documented := make(map[string]bool)
+		for _, route := range openapi.Routes {
+			documented[route.Method+" "+route.Pattern] = true
+		}
+
+		// Act
+		registered := make(map[string]bool)
+		err := chi.Walk(router, func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+			registered[method+" "+route] = true
+			return nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, documented, registered, "routes registered in newRouter and listed in openapi.Routes differ")
+	})
+}
+
+func TestDeprecatedV1(t *testing.T) {
+	router := newRouter(config.Default())
+
+	for _, path := range []string{"/presentations/invalid/polls/current", "/v1/presentations/invalid/polls/current"} {
+		t.Run(path, func(t *testing.T) {
+			// Arrange
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, path, nil)
+
+			// Act
+			router.ServeHTTP(w, r)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, "true", w.Header().Get("Deprecation"))
+			assert.Equal(t, `</v2/presentations/invalid/polls/current>; rel="successor-version"`, w.Header().Get("Link"))
+		})
+	}
+
+	t.Run("V2", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/v2/presentations/invalid/polls/current", nil)
+
+		// Act
+		router.ServeHTTP(w, r)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, w.Header().Get("Deprecation"))
+	})
+}
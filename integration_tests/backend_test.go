@@ -43,6 +43,7 @@ func TestBackend(t *testing.T) {
 
 	t.Run("creating a presentation, showing a poll, voting and reading votes", func(t *testing.T) {
 		var presentationID string
+		var presenterToken string
 		var pollID uuid.UUID
 
 		// Create a presentation
@@ -80,6 +81,8 @@ func TestBackend(t *testing.T) {
 		assert.NoError(t, err)
 		presentationID = result["presentation_id"]
 		assert.NotEmpty(t, presentationID, "Presentation ID is present when creating a presentation")
+		presenterToken = result["presenter_token"]
+		assert.NotEmpty(t, presenterToken, "Presenter token is present when creating a presentation")
 
 		// Get the current poll
 		resp, err = http.Get(apiUrl + "/presentations/" + presentationID + "/polls/current")
@@ -93,12 +96,20 @@ func TestBackend(t *testing.T) {
 		assert.NotEmpty(t, pollID, "poll_id should be returned")
 		assert.Equal(t, "What's your favorite pet?", poll.Question)
 
-		// Present the next poll
+		// Presenting the next poll without the presenter token is rejected
 		req, err := http.NewRequest("PUT", apiUrl+"/presentations/"+presentationID+"/polls/current", nil)
 		assert.NoError(t, err)
 		client := &http.Client{}
 		resp, err = client.Do(req)
 		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "switching to the next poll without a presenter token must return 401 http code")
+
+		// Present the next poll
+		req, err = http.NewRequest("PUT", apiUrl+"/presentations/"+presentationID+"/polls/current", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+presenterToken)
+		resp, err = client.Do(req)
+		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, resp.StatusCode, "switching to the next poll must return 200 http code")
 
 		var poll2 models.Poll
@@ -109,7 +120,10 @@ func TestBackend(t *testing.T) {
 		assert.Equal(t, "Which of the countries would you like to visit the most?", poll2.Question)
 
 		// Get the votes for the current poll
-		resp, err = http.Get(apiUrl + "/presentations/" + presentationID + "/polls/" + pollID.String() + "/votes")
+		req, err = http.NewRequest("GET", apiUrl+"/presentations/"+presentationID+"/polls/"+pollID.String()+"/votes", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+presenterToken)
+		resp, err = client.Do(req)
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, resp.StatusCode, "reading presentation votes should return 200")
 
@@ -120,7 +134,7 @@ func TestBackend(t *testing.T) {
 
 		// Vote for the current poll
 		clientID := uuid.NewString()
-		key, err := generateRandomCapitalLetter()
+		key, err := randomOptionKey(poll2.Options)
 		assert.NoError(t, err)
 		voteBody := models.Vote{
 			Key:      key,
@@ -139,7 +153,10 @@ func TestBackend(t *testing.T) {
 		assert.Equal(t, http.StatusNoContent, resp.StatusCode, "voting should return 204 status code")
 
 		// Verify the vote was recorded
-		resp, err = http.Get(apiUrl + "/presentations/" + presentationID + "/polls/" + pollID.String() + "/votes")
+		req, err = http.NewRequest("GET", apiUrl+"/presentations/"+presentationID+"/polls/"+pollID.String()+"/votes", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+presenterToken)
+		resp, err = client.Do(req)
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, resp.StatusCode, "reading votes should return 200 status code")
 
@@ -155,11 +172,10 @@ func TestBackend(t *testing.T) {
 	})
 }
 
-func generateRandomCapitalLetter() (string, error) {
-	const letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
-	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(letters))))
+func randomOptionKey(options []models.Option) (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(options))))
 	if err != nil {
 		return "", err
 	}
-	return string(letters[n.Int64()]), nil
+	return options[n.Int64()].Key, nil
 }
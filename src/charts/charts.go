@@ -0,0 +1,292 @@
+// Package charts renders bar and pie charts of poll results as SVG and PNG
+// images.
+package charts
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+type Kind string
+
+const (
+	Bar Kind = "bar"
+	Pie Kind = "pie"
+)
+
+const (
+	Width  = 640
+	Height = 400
+
+	padding     = 20
+	titleHeight = 40
+	maxRow      = 48
+	// charWidth is the advance of basicfont.Face7x13, used to truncate text so
+	// it fits the PNG layout.
+	charWidth = 7
+)
+
+var (
+	background = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	foreground = color.RGBA{R: 0x22, G: 0x22, B: 0x22, A: 0xff}
+	empty      = color.RGBA{R: 0xdd, G: 0xdd, B: 0xdd, A: 0xff}
+	palette    = []color.RGBA{
+		{R: 0x4e, G: 0x79, B: 0xa7, A: 0xff},
+		{R: 0xf2, G: 0x8e, B: 0x2b, A: 0xff},
+		{R: 0xe1, G: 0x57, B: 0x59, A: 0xff},
+		{R: 0x76, G: 0xb7, B: 0xb2, A: 0xff},
+		{R: 0x59, G: 0xa1, B: 0x4f, A: 0xff},
+		{R: 0xed, G: 0xc9, B: 0x48, A: 0xff},
+		{R: 0xb0, G: 0x7a, B: 0xa1, A: 0xff},
+		{R: 0xff, G: 0x9d, B: 0xa7, A: 0xff},
+	}
+)
+
+type Item struct {
+	Label string
+	Value int
+}
+
+type Chart struct {
+	Title string
+	Items []Item
+}
+
+// ParseKind maps a chart type name to its Kind. An empty name selects Bar.
+func ParseKind(name string) (Kind, error) {
+	switch Kind(strings.ToLower(name)) {
+	case "", Bar:
+		return Bar, nil
+	case Pie:
+		return Pie, nil
+	default:
+		return "", fmt.Errorf("unknown chart type: %s", name)
+	}
+}
+
+func (c Chart) total() int {
+	total := 0
+	for _, item := range c.Items {
+		total += item.Value
+	}
+	return total
+}
+
+func (c Chart) maxValue() int {
+	maxValue := 1
+	for _, item := range c.Items {
+		maxValue = max(maxValue, item.Value)
+	}
+	return maxValue
+}
+
+func (c Chart) percentage(value int) float64 {
+	total := c.total()
+	if total == 0 {
+		return 0
+	}
+	return float64(value) * 100 / float64(total)
+}
+
+// bar describes the geometry of one row of a bar chart.
+type bar struct {
+	labelY int
+	y      int
+	height int
+	length int
+}
+
+func (c Chart) bars() (labelWidth int, bars []bar) {
+	labelWidth = Width * 35 / 100
+	barMax := Width - labelWidth - 2*padding - 60
+	rowHeight := maxRow
+	if len(c.Items) > 0 {
+		rowHeight = min(maxRow, (Height-titleHeight-2*padding)/len(c.Items))
+	}
+
+	for i, item := range c.Items {
+		top := titleHeight + padding + i*rowHeight
+		height := rowHeight * 6 / 10
+		bars = append(bars, bar{
+			labelY: top + rowHeight/2 + 4,
+			y:      top + (rowHeight-height)/2,
+			height: height,
+			length: item.Value * barMax / c.maxValue(),
+		})
+	}
+	return labelWidth, bars
+}
+
+// slice describes one slice of a pie chart as fractions of a full turn,
+// starting at twelve o'clock and going clockwise.
+type slice struct {
+	from float64
+	to   float64
+}
+
+func (c Chart) slices() []slice {
+	total := c.total()
+	var slices []slice
+	from := 0.0
+	for _, item := range c.Items {
+		to := from
+		if total > 0 {
+			to += float64(item.Value) / float64(total)
+		}
+		slices = append(slices, slice{from: from, to: to})
+		from = to
+	}
+	return slices
+}
+
+func pieGeometry() (cx, cy, radius int) {
+	radius = (Height - titleHeight - 2*padding) / 2
+	return padding + radius, titleHeight + padding + radius, radius
+}
+
+func SVG(chart Chart, kind Kind) []byte {
+	var svg strings.Builder
+	_, _ = fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`, Width, Height, Width, Height)
+	_, _ = fmt.Fprintf(&svg, `<rect width="100%%" height="100%%" fill="%s"/>`, hex(background))
+	_, _ = fmt.Fprintf(&svg, `<text x="%d" y="%d" font-size="18" font-weight="bold" text-anchor="middle" fill="%s">%s</text>`, Width/2, titleHeight-12, hex(foreground), escape(chart.Title))
+
+	switch kind {
+	case Pie:
+		cx, cy, radius := pieGeometry()
+		if chart.total() == 0 {
+			_, _ = fmt.Fprintf(&svg, `<circle cx="%d" cy="%d" r="%d" fill="%s"/>`, cx, cy, radius, hex(empty))
+		}
+		for i, s := range chart.slices() {
+			fill := hex(palette[i%len(palette)])
+			switch {
+			case s.to-s.from >= 1:
+				_, _ = fmt.Fprintf(&svg, `<circle cx="%d" cy="%d" r="%d" fill="%s"/>`, cx, cy, radius, fill)
+			case s.to > s.from:
+				x1, y1 := pointOnCircle(cx, cy, radius, s.from)
+				x2, y2 := pointOnCircle(cx, cy, radius, s.to)
+				largeArc := 0
+				if s.to-s.from > 0.5 {
+					largeArc = 1
+				}
+				_, _ = fmt.Fprintf(&svg, `<path d="M%d %d L%.2f %.2f A%d %d 0 %d 1 %.2f %.2f Z" fill="%s"/>`, cx, cy, x1, y1, radius, radius, largeArc, x2, y2, fill)
+			}
+		}
+		legendX := cx + radius + 2*padding
+		for i, item := range chart.Items {
+			y := titleHeight + padding + i*24
+			_, _ = fmt.Fprintf(&svg, `<rect x="%d" y="%d" width="14" height="14" fill="%s"/>`, legendX, y, hex(palette[i%len(palette)]))
+			_, _ = fmt.Fprintf(&svg, `<text x="%d" y="%d" font-size="14" fill="%s">%s: %d (%.0f%%)</text>`, legendX+22, y+12, hex(foreground), escape(item.Label), item.Value, chart.percentage(item.Value))
+		}
+	default:
+		labelWidth, bars := chart.bars()
+		for i, item := range chart.Items {
+			b := bars[i]
+			_, _ = fmt.Fprintf(&svg, `<text x="%d" y="%d" font-size="14" text-anchor="end" fill="%s">%s</text>`, padding+labelWidth-10, b.labelY, hex(foreground), escape(item.Label))
+			_, _ = fmt.Fprintf(&svg, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`, padding+labelWidth, b.y, b.length, b.height, hex(palette[i%len(palette)]))
+			_, _ = fmt.Fprintf(&svg, `<text x="%d" y="%d" font-size="14" fill="%s">%d</text>`, padding+labelWidth+b.length+8, b.labelY, hex(foreground), item.Value)
+		}
+	}
+
+	svg.WriteString(`</svg>`)
+	return []byte(svg.String())
+}
+
+func PNG(chart Chart, kind Kind) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: background}, image.Point{}, draw.Src)
+
+	title := truncate(chart.Title, (Width-2*padding)/charWidth)
+	drawText(img, (Width-len([]rune(title))*charWidth)/2, titleHeight-14, title)
+
+	switch kind {
+	case Pie:
+		cx, cy, radius := pieGeometry()
+		slices := chart.slices()
+		for y := cy - radius; y <= cy+radius; y++ {
+			for x := cx - radius; x <= cx+radius; x++ {
+				dx, dy := float64(x-cx), float64(y-cy)
+				if dx*dx+dy*dy > float64(radius*radius) {
+					continue
+				}
+				turn := math.Mod(math.Atan2(dy, dx)/(2*math.Pi)+1.25, 1)
+				fill := empty
+				for i, s := range slices {
+					if turn >= s.from && turn < s.to {
+						fill = palette[i%len(palette)]
+						break
+					}
+				}
+				img.SetRGBA(x, y, fill)
+			}
+		}
+		legendX := cx + radius + 2*padding
+		for i, item := range chart.Items {
+			y := titleHeight + padding + i*24
+			draw.Draw(img, image.Rect(legendX, y, legendX+14, y+14), &image.Uniform{C: palette[i%len(palette)]}, image.Point{}, draw.Src)
+			legend := fmt.Sprintf("%s: %d (%.0f%%)", item.Label, item.Value, chart.percentage(item.Value))
+			drawText(img, legendX+22, y+11, truncate(legend, (Width-legendX-22-padding)/charWidth))
+		}
+	default:
+		labelWidth, bars := chart.bars()
+		for i, item := range chart.Items {
+			b := bars[i]
+			label := truncate(item.Label, (labelWidth-10)/charWidth)
+			drawText(img, padding+labelWidth-10-len([]rune(label))*charWidth, b.labelY, label)
+			draw.Draw(img, image.Rect(padding+labelWidth, b.y, padding+labelWidth+b.length, b.y+b.height), &image.Uniform{C: palette[i%len(palette)]}, image.Point{}, draw.Src)
+			drawText(img, padding+labelWidth+b.length+8, b.labelY, fmt.Sprint(item.Value))
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		return nil, fmt.Errorf("error encoding png: %v", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+func pointOnCircle(cx, cy, radius int, turn float64) (float64, float64) {
+	angle := turn*2*math.Pi - math.Pi/2
+	return float64(cx) + float64(radius)*math.Cos(angle), float64(cy) + float64(radius)*math.Sin(angle)
+}
+
+func drawText(img draw.Image, x, y int, text string) {
+	drawer := font.Drawer{
+		Dst:  img,
+		Src:  &image.Uniform{C: foreground},
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	drawer.DrawString(text)
+}
+
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if length < 1 {
+		return ""
+	}
+	if len(runes) > length {
+		return string(runes[:length-1]) + "…"
+	}
+	return text
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func escape(text string) string {
+	var escaped bytes.Buffer
+	_ = xml.EscapeText(&escaped, []byte(text))
+	return escaped.String()
+}
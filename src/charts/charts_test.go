@@ -0,0 +1,83 @@
+package charts
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var chart = Chart{
+	Title: "What's your favorite pet?",
+	Items: []Item{
+		{Label: "Dog", Value: 3},
+		{Label: "Cat", Value: 1},
+		{Label: "Crocodile", Value: 0},
+	},
+}
+
+func TestParseKind(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Act
+		kind, err := ParseKind("PIE")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, Pie, kind)
+	})
+
+	t.Run("Unknown Kind", func(t *testing.T) {
+		// Act
+		_, err := ParseKind("radar")
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestSVG(t *testing.T) {
+	t.Run("Bar Chart", func(t *testing.T) {
+		// Act
+		svg := string(SVG(chart, Bar))
+
+		// Assert
+		assert.Contains(t, svg, "What&#39;s your favorite pet?")
+		assert.Contains(t, svg, ">Crocodile</text>")
+		assert.Equal(t, 3, bytes.Count([]byte(svg), []byte(`<rect x=`)))
+	})
+
+	t.Run("Pie Chart", func(t *testing.T) {
+		// Act
+		svg := string(SVG(chart, Pie))
+
+		// Assert
+		assert.Contains(t, svg, "Dog: 3 (75%)")
+		assert.Equal(t, 2, bytes.Count([]byte(svg), []byte(`<path `)))
+	})
+
+	t.Run("Pie Chart Without Votes", func(t *testing.T) {
+		// Act
+		svg := string(SVG(Chart{Title: "Empty", Items: []Item{{Label: "A"}}}, Pie))
+
+		// Assert
+		assert.Contains(t, svg, "<circle ")
+		assert.NotContains(t, svg, "<path ")
+	})
+}
+
+func TestPNG(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		for _, kind := range []Kind{Bar, Pie} {
+			// Act
+			data, err := PNG(chart, kind)
+
+			// Assert
+			assert.NoError(t, err)
+			img, err := png.Decode(bytes.NewReader(data))
+			assert.NoError(t, err)
+			assert.Equal(t, Width, img.Bounds().Dx())
+			assert.Equal(t, Height, img.Bounds().Dy())
+		}
+	})
+}
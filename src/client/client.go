@@ -0,0 +1,196 @@
+// Package client calls the HTTP API of the service on behalf of the command
+// line tools.
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"interactive-presentation/src/models"
+)
+
+// apiPrefix is the API version every request of the client is sent to.
+const apiPrefix = "/v2"
+
+type Client struct {
+	BaseURL string
+	// Token is the presenter token sent with every request when set.
+	Token string
+	HTTP  *http.Client
+}
+
+// StatusError is returned when the service answers with an unexpected status
+// code. For problem details responses Code holds the stable error code and
+// Body the detail, or the title when there is none.
+type StatusError struct {
+	StatusCode int
+	Code       string
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+func New(baseURL string, token string) *Client {
+	return &Client{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Token:   token,
+		HTTP:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// CreatePresentation creates a presentation and returns the raw response
+// holding its ID, presenter token and join code.
+func (c *Client) CreatePresentation(presentation models.Presentation) ([]byte, error) {
+	body, err := json.Marshal(presentation)
+	if err != nil {
+		return nil, err
+	}
+	return c.call(http.MethodPost, "/presentations", "application/json", body, http.StatusCreated)
+}
+
+// ImportPresentation creates a presentation from a YAML, JSON or Markdown file
+// and returns the raw response holding its ID, presenter token and join code.
+func (c *Client) ImportPresentation(format string, data []byte) ([]byte, error) {
+	return c.call(http.MethodPost, "/presentations/import?format="+url.QueryEscape(format), "application/octet-stream", data, http.StatusCreated)
+}
+
+func (c *Client) CurrentPoll(presentationID uuid.UUID) (models.PollV2, error) {
+	var poll models.PollV2
+	err := c.callJSON(http.MethodGet, "/presentations/"+presentationID.String()+"/polls/current", nil, http.StatusOK, &poll)
+	return poll, err
+}
+
+// NextPoll advances the presentation to its next poll and returns it.
+func (c *Client) NextPoll(presentationID uuid.UUID) (models.PollV2, error) {
+	var poll models.PollV2
+	err := c.callJSON(http.MethodPut, "/presentations/"+presentationID.String()+"/polls/current", nil, http.StatusOK, &poll)
+	return poll, err
+}
+
+// ShowPoll makes the poll at index the current poll and returns it.
+func (c *Client) ShowPoll(presentationID uuid.UUID, index int) (models.PollV2, error) {
+	var poll models.PollV2
+	err := c.callJSON(http.MethodPut, "/presentations/"+presentationID.String()+"/polls/current", models.PollIndex{Index: &index}, http.StatusOK, &poll)
+	return poll, err
+}
+
+// SetCurrentPollStatus opens or closes the current poll for voting.
+func (c *Client) SetCurrentPollStatus(presentationID uuid.UUID, status string) error {
+	body, err := json.Marshal(models.PollStatus{Status: status})
+	if err != nil {
+		return err
+	}
+	_, err = c.call(http.MethodPut, "/presentations/"+presentationID.String()+"/polls/current/status", "application/json", body, http.StatusNoContent)
+	return err
+}
+
+// Export streams an export of every vote of a presentation in the given
+// format. The caller closes the returned reader.
+func (c *Client) Export(presentationID uuid.UUID, format string) (io.ReadCloser, error) {
+	resp, err := c.do(http.MethodGet, "/presentations/"+presentationID.String()+"/export?format="+url.QueryEscape(format), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_, err = read(resp, http.StatusOK)
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (c *Client) callJSON(method string, path string, request interface{}, expected int, response interface{}) error {
+	var body []byte
+	contentType := ""
+	if request != nil {
+		var err error
+		if body, err = json.Marshal(request); err != nil {
+			return err
+		}
+		contentType = "application/json"
+	}
+
+	responseBody, err := c.call(method, path, contentType, body, expected)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(responseBody, response); err != nil {
+		return fmt.Errorf("error parsing response: %v", err)
+	}
+	return nil
+}
+
+func (c *Client) call(method string, path string, contentType string, body []byte, expected int) ([]byte, error) {
+	resp, err := c.do(method, path, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return read(resp, expected)
+}
+
+func (c *Client) do(method string, path string, contentType string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, c.BaseURL+apiPrefix+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error calling %s: %v", req.URL, err)
+	}
+	return resp, nil
+}
+
+func read(resp *http.Response, expected int) ([]byte, error) {
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %v", err)
+	}
+	if resp.StatusCode != expected {
+		return nil, statusError(resp, body)
+	}
+	return body, nil
+}
+
+func statusError(resp *http.Response, body []byte) *StatusError {
+	statusError := &StatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "application/problem+json" {
+		return statusError
+	}
+	var problem struct {
+		Title  string `json:"title"`
+		Code   string `json:"code"`
+		Detail string `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return statusError
+	}
+	statusError.Code = problem.Code
+	statusError.Body = problem.Title
+	if problem.Detail != "" {
+		statusError.Body = problem.Detail
+	}
+	return statusError
+}
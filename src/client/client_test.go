@@ -0,0 +1,77 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"interactive-presentation/src/models"
+)
+
+func TestClientShowPoll(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		presentationID := uuid.New()
+		var request *http.Request
+		var body models.PollIndex
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			request = r
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_ = json.NewEncoder(w).Encode(models.PollV2{Question: "What's your favorite pet?"})
+		}))
+		defer server.Close()
+
+		// Act
+		poll, err := New(server.URL+"/", "secret").ShowPoll(presentationID, 2)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "What's your favorite pet?", poll.Question)
+		assert.Equal(t, http.MethodPut, request.Method)
+		assert.Equal(t, "/v2/presentations/"+presentationID.String()+"/polls/current", request.URL.Path)
+		assert.Equal(t, "Bearer secret", request.Header.Get("Authorization"))
+		assert.Equal(t, 2, *body.Index)
+	})
+
+	t.Run("Unexpected Status", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Presenter token required", http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		// Act
+		_, err := New(server.URL, "").ShowPoll(uuid.New(), 0)
+
+		// Assert
+		var statusError *StatusError
+		assert.True(t, errors.As(err, &statusError))
+		assert.Equal(t, http.StatusUnauthorized, statusError.StatusCode)
+		assert.Equal(t, "Presenter token required", statusError.Body)
+	})
+
+	t.Run("Problem Details", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"type":"/problems/poll_closed","title":"Poll is closed","status":409,"code":"poll_closed"}`))
+		}))
+		defer server.Close()
+
+		// Act
+		_, err := New(server.URL, "").ShowPoll(uuid.New(), 0)
+
+		// Assert
+		var statusError *StatusError
+		assert.True(t, errors.As(err, &statusError))
+		assert.Equal(t, http.StatusConflict, statusError.StatusCode)
+		assert.Equal(t, "poll_closed", statusError.Code)
+		assert.Equal(t, "Poll is closed", statusError.Body)
+	})
+}
@@ -1,21 +1,417 @@
+// Package config loads the service configuration from defaults, a YAML or
+// TOML file, environment variables and command-line flags, in increasing
+// order of precedence.
 package config
 
 import (
 	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"interactive-presentation/src/tracing"
 )
 
+// DefaultUpstreamURL is the presentation service the polls are created with.
+const DefaultUpstreamURL = "https://infra.devskills.app/api/interactive-presentation/v4"
+
 type Config struct {
 	DatabaseURL string
+	PublicURL   string
+	// TrustProxyHeaders lets join links follow the X-Forwarded-Proto header
+	// when PublicURL is not set. Only enable it behind a proxy that sets it.
+	TrustProxyHeaders bool
+	// AllowedHosts are the Host headers join links may be built from when
+	// PublicURL is not set, so clients cannot point QR codes elsewhere.
+	AllowedHosts []string
+	// ListenAddress is the TCP address the HTTP server listens on.
+	ListenAddress string
+	// UpstreamURL is the base URL of the presentation service.
+	UpstreamURL string
+	// UpstreamTimeout limits every call to the presentation service.
+	UpstreamTimeout time.Duration
+	// LogLevel is the minimum level of log lines written, one of debug, info,
+	// warn or error.
+	LogLevel slog.Level
+	// TracesExporter is where spans are sent to, one of none, otlp or stdout.
+	TracesExporter string
+
+	// DBMaxOpenConns and DBMaxIdleConns size the database connection pool,
+	// zero leaves the number of open connections unlimited.
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration
+	DBConnMaxIdleTime time.Duration
+
+	// ReadTimeout, WriteTimeout and IdleTimeout limit the HTTP server's
+	// connections, live streams are exempt from the write timeout.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// ShutdownTimeout is how long in-flight requests may take to finish
+	// after SIGTERM before their connections are closed.
+	ShutdownTimeout time.Duration
+
+	// CORSAllowedOrigins are the origins browsers may call the API from, "*"
+	// allows every origin. CORS is disabled when empty.
+	CORSAllowedOrigins []string
+	// ReactionsPerSecond is the number of reactions a client may send per
+	// second.
+	ReactionsPerSecond int
+	// JoinCodeTTL is how long join codes stay valid.
+	JoinCodeTTL time.Duration
+	// IdempotencyKeyTTL is how long responses to requests with an
+	// Idempotency-Key header are kept for replay.
+	IdempotencyKeyTTL time.Duration
+
+	// Feature toggles.
+	WebApp      bool
+	Reactions   bool
+	Submissions bool
+	Metrics     bool
 }
 
-func New() (*Config, error) {
-	dbURL, found := os.LookupEnv("DATABASE_URL")
-	if !found {
-		return nil, errors.New("DATABASE_URL not found")
+// setting describes one configuration value. Its key is used in files, with
+// dashes as flag name and upper-cased as environment variable unless env is
+// set.
+type setting struct {
+	key   string
+	env   string
+	usage string
+	set   func(c *Config, value string) error
+}
+
+func (s setting) envName() string {
+	if s.env != "" {
+		return s.env
+	}
+	return strings.ToUpper(s.key)
+}
+
+func (s setting) flagName() string {
+	return strings.ReplaceAll(s.key, "_", "-")
+}
+
+var settings = []setting{
+	{key: "database_url", usage: "PostgreSQL connection URL", set: func(c *Config, v string) error {
+		c.DatabaseURL = v
+		return nil
+	}},
+	{key: "public_url", usage: "public base URL of the service used in join links", set: func(c *Config, v string) error {
+		c.PublicURL = strings.TrimSuffix(v, "/")
+		return nil
+	}},
+	{key: "trust_proxy_headers", usage: "use the X-Forwarded-Proto header of a reverse proxy in join links without public_url", set: boolSetter(func(c *Config) *bool { return &c.TrustProxyHeaders })},
+	{key: "allowed_hosts", usage: "comma separated hosts join links may use without public_url", set: func(c *Config, v string) error {
+		c.AllowedHosts = nil
+		for _, host := range strings.Split(v, ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				c.AllowedHosts = append(c.AllowedHosts, strings.ToLower(host))
+			}
+		}
+		return nil
+	}},
+	{key: "listen_address", usage: "address the HTTP server listens on", set: func(c *Config, v string) error {
+		c.ListenAddress = v
+		return nil
+	}},
+	{key: "upstream_url", usage: "base URL of the upstream presentation service", set: func(c *Config, v string) error {
+		c.UpstreamURL = strings.TrimSuffix(v, "/")
+		return nil
+	}},
+	{key: "upstream_timeout", usage: "timeout of calls to the upstream presentation service", set: durationSetter(func(c *Config) *time.Duration { return &c.UpstreamTimeout })},
+	{key: "log_level", usage: "minimum log level: debug, info, warn or error", set: func(c *Config, v string) error {
+		if err := c.LogLevel.UnmarshalText([]byte(v)); err != nil {
+			return errors.New("must be one of debug, info, warn or error")
+		}
+		return nil
+	}},
+	{key: "traces_exporter", env: "OTEL_TRACES_EXPORTER", usage: "trace exporter: none, otlp or stdout", set: func(c *Config, v string) error {
+		c.TracesExporter = v
+		return nil
+	}},
+	{key: "db_max_open_conns", usage: "maximum number of open database connections, 0 for unlimited", set: intSetter(func(c *Config) *int { return &c.DBMaxOpenConns })},
+	{key: "db_max_idle_conns", usage: "maximum number of idle database connections", set: intSetter(func(c *Config) *int { return &c.DBMaxIdleConns })},
+	{key: "db_conn_max_lifetime", usage: "maximum lifetime of a database connection", set: durationSetter(func(c *Config) *time.Duration { return &c.DBConnMaxLifetime })},
+	{key: "db_conn_max_idle_time", usage: "maximum idle time of a database connection", set: durationSetter(func(c *Config) *time.Duration { return &c.DBConnMaxIdleTime })},
+	{key: "http_read_timeout", usage: "HTTP server read timeout", set: durationSetter(func(c *Config) *time.Duration { return &c.ReadTimeout })},
+	{key: "http_write_timeout", usage: "HTTP server write timeout", set: durationSetter(func(c *Config) *time.Duration { return &c.WriteTimeout })},
+	{key: "http_idle_timeout", usage: "HTTP server idle connection timeout", set: durationSetter(func(c *Config) *time.Duration { return &c.IdleTimeout })},
+	{key: "shutdown_timeout", usage: "time in-flight requests get to finish on shutdown", set: durationSetter(func(c *Config) *time.Duration { return &c.ShutdownTimeout })},
+	{key: "cors_allowed_origins", usage: "comma separated origins allowed to call the API, * for any", set: func(c *Config, v string) error {
+		c.CORSAllowedOrigins = nil
+		for _, origin := range strings.Split(v, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				c.CORSAllowedOrigins = append(c.CORSAllowedOrigins, origin)
+			}
+		}
+		return nil
+	}},
+	{key: "reactions_per_second", usage: "reactions a client may send per second", set: intSetter(func(c *Config) *int { return &c.ReactionsPerSecond })},
+	{key: "join_code_ttl", usage: "validity of join codes", set: durationSetter(func(c *Config) *time.Duration { return &c.JoinCodeTTL })},
+	{key: "idempotency_key_ttl", usage: "how long responses are kept for replay by Idempotency-Key", set: durationSetter(func(c *Config) *time.Duration { return &c.IdempotencyKeyTTL })},
+	{key: "enable_web_app", usage: "serve the audience and presenter web app", set: boolSetter(func(c *Config) *bool { return &c.WebApp })},
+	{key: "enable_reactions", usage: "accept and stream emoji reactions", set: boolSetter(func(c *Config) *bool { return &c.Reactions })},
+	{key: "enable_submissions", usage: "accept free text submissions", set: boolSetter(func(c *Config) *bool { return &c.Submissions })},
+	{key: "enable_metrics", usage: "expose Prometheus metrics at /metrics", set: boolSetter(func(c *Config) *bool { return &c.Metrics })},
+}
+
+func intSetter(field func(*Config) *int) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		value, err := strconv.Atoi(v)
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		*field(c) = value
+		return nil
+	}
+}
+
+func durationSetter(field func(*Config) *time.Duration) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		value, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.New("must be a duration such as 30s or 5m")
+		}
+		*field(c) = value
+		return nil
 	}
+}
 
+func boolSetter(field func(*Config) *bool) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		value, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.New("must be true or false")
+		}
+		*field(c) = value
+		return nil
+	}
+}
+
+// Default returns the configuration used for every setting that is not set
+// explicitly.
+func Default() *Config {
 	return &Config{
-		DatabaseURL: dbURL,
-	}, nil
+		AllowedHosts:       []string{"localhost", "127.0.0.1"},
+		ListenAddress:      ":8080",
+		UpstreamURL:        DefaultUpstreamURL,
+		UpstreamTimeout:    10 * time.Second,
+		TracesExporter:     tracing.ExporterNone,
+		DBMaxOpenConns:     25,
+		DBMaxIdleConns:     5,
+		DBConnMaxLifetime:  30 * time.Minute,
+		DBConnMaxIdleTime:  5 * time.Minute,
+		ReadTimeout:        10 * time.Second,
+		WriteTimeout:       30 * time.Second,
+		IdleTimeout:        120 * time.Second,
+		ShutdownTimeout:    15 * time.Second,
+		ReactionsPerSecond: 5,
+		JoinCodeTTL:        24 * time.Hour,
+		IdempotencyKeyTTL:  24 * time.Hour,
+		WebApp:             true,
+		Reactions:          true,
+		Submissions:        true,
+		Metrics:            true,
+	}
+}
+
+// New loads the configuration from the file in CONFIG_FILE, if set, and the
+// environment.
+func New() (*Config, error) {
+	return Load(nil, io.Discard)
+}
+
+// Load loads the configuration from the file given with -config or in
+// CONFIG_FILE, the environment and the flags in args, each taking precedence
+// over the one before. Usage is written to output when args ask for help.
+func Load(args []string, output io.Writer) (*Config, error) {
+	flagValues := map[string]string{}
+	flags := flag.NewFlagSet("service", flag.ContinueOnError)
+	flags.SetOutput(output)
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "YAML or TOML configuration file (CONFIG_FILE)")
+	for _, s := range settings {
+		s := s
+		flags.Func(s.flagName(), fmt.Sprintf("%s (%s)", s.usage, s.envName()), func(value string) error {
+			if err := s.set(Default(), value); err != nil {
+				return err
+			}
+			flagValues[s.key] = value
+			return nil
+		})
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	configuration := Default()
+	var problems []error
+
+	if *configFile != "" {
+		values, err := readFile(*configFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range settings {
+			if value, found := values[s.key]; found {
+				if err = s.set(configuration, value); err != nil {
+					problems = append(problems, fmt.Errorf("%s in %s %v", s.key, *configFile, err))
+				}
+				delete(values, s.key)
+			}
+		}
+		unknown := make([]string, 0, len(values))
+		for key := range values {
+			unknown = append(unknown, key)
+		}
+		sort.Strings(unknown)
+		for _, key := range unknown {
+			problems = append(problems, fmt.Errorf("%s in %s is not a known setting", key, *configFile))
+		}
+	}
+
+	for _, s := range settings {
+		if value, found := os.LookupEnv(s.envName()); found {
+			if err := s.set(configuration, value); err != nil {
+				problems = append(problems, fmt.Errorf("%s %v", s.envName(), err))
+			}
+		}
+	}
+
+	for _, s := range settings {
+		if value, found := flagValues[s.key]; found {
+			_ = s.set(configuration, value)
+		}
+	}
+
+	problems = append(problems, configuration.validate()...)
+	if len(problems) > 0 {
+		return nil, invalidConfigurationError(problems)
+	}
+	return configuration, nil
+}
+
+// readFile reads the settings of a YAML or TOML file, told apart by its
+// extension, as strings.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading configuration file: %v", err)
+	}
+
+	raw := map[string]interface{}{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("configuration file %s must end in .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing configuration file %s: %v", path, err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		switch value := value.(type) {
+		case []interface{}:
+			var items []string
+			for _, item := range value {
+				items = append(items, fmt.Sprint(item))
+			}
+			values[key] = strings.Join(items, ",")
+		default:
+			values[key] = fmt.Sprint(value)
+		}
+	}
+	return values, nil
+}
+
+func (c *Config) validate() []error {
+	var problems []error
+	if c.DatabaseURL == "" {
+		problems = append(problems, errors.New("database_url is required, set DATABASE_URL"))
+	}
+	if c.ListenAddress == "" {
+		problems = append(problems, errors.New("listen_address must not be empty"))
+	}
+	if !isHTTPURL(c.UpstreamURL) {
+		problems = append(problems, fmt.Errorf("upstream_url must be an http or https URL, got %q", c.UpstreamURL))
+	}
+	if c.PublicURL != "" && !isHTTPURL(c.PublicURL) {
+		problems = append(problems, fmt.Errorf("public_url must be an http or https URL, got %q", c.PublicURL))
+	}
+	if !tracing.ValidExporter(c.TracesExporter) {
+		problems = append(problems, fmt.Errorf("traces_exporter must be one of none, otlp or stdout, got %q", c.TracesExporter))
+	}
+	if c.DBMaxOpenConns < 0 || c.DBMaxIdleConns < 0 {
+		problems = append(problems, errors.New("db_max_open_conns and db_max_idle_conns must not be negative"))
+	}
+	if c.DBMaxOpenConns > 0 && c.DBMaxIdleConns > c.DBMaxOpenConns {
+		problems = append(problems, fmt.Errorf("db_max_idle_conns (%d) must not exceed db_max_open_conns (%d)", c.DBMaxIdleConns, c.DBMaxOpenConns))
+	}
+	if c.DBConnMaxLifetime < 0 || c.DBConnMaxIdleTime < 0 {
+		problems = append(problems, errors.New("db_conn_max_lifetime and db_conn_max_idle_time must not be negative"))
+	}
+	timeouts := []struct {
+		key   string
+		value time.Duration
+	}{
+		{"upstream_timeout", c.UpstreamTimeout},
+		{"http_read_timeout", c.ReadTimeout},
+		{"http_write_timeout", c.WriteTimeout},
+		{"http_idle_timeout", c.IdleTimeout},
+		{"shutdown_timeout", c.ShutdownTimeout},
+	}
+	for _, timeout := range timeouts {
+		if timeout.value <= 0 {
+			problems = append(problems, fmt.Errorf("%s must be positive", timeout.key))
+		}
+	}
+	for _, origin := range c.CORSAllowedOrigins {
+		if origin != "*" && !isHTTPURL(origin) {
+			problems = append(problems, fmt.Errorf("cors_allowed_origins must hold * or http(s) origins, got %q", origin))
+		}
+	}
+	if c.ReactionsPerSecond < 1 {
+		problems = append(problems, errors.New("reactions_per_second must be at least 1"))
+	}
+	if c.JoinCodeTTL < time.Minute {
+		problems = append(problems, errors.New("join_code_ttl must be at least 1m"))
+	}
+	if c.IdempotencyKeyTTL < time.Minute {
+		problems = append(problems, errors.New("idempotency_key_ttl must be at least 1m"))
+	}
+	return problems
+}
+
+func isHTTPURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// invalidConfigurationError lists every problem found, so they can all be
+// fixed at once.
+type invalidConfigurationError []error
+
+func (e invalidConfigurationError) Error() string {
+	var message strings.Builder
+	message.WriteString("invalid configuration:")
+	for _, problem := range e {
+		message.WriteString("\n  - ")
+		message.WriteString(problem.Error())
+	}
+	return message.String()
 }
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		t.Setenv("DATABASE_URL", "postgres://localhost/presentations")
+
+		// Act
+		configuration, err := Load(nil, io.Discard)
+
+		// Assert
+		assert.NoError(t, err)
+		expected := Default()
+		expected.DatabaseURL = "postgres://localhost/presentations"
+		assert.Equal(t, expected, configuration)
+	})
+
+	t.Run("Precedence", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "service.yaml", `
+database_url: postgres://file/presentations
+listen_address: ":9000"
+reactions_per_second: 3
+join_code_ttl: 2h
+cors_allowed_origins:
+  - https://slides.example.com
+  - https://audience.example.com
+`)
+		t.Setenv("LISTEN_ADDRESS", ":9001")
+		t.Setenv("REACTIONS_PER_SECOND", "4")
+
+		// Act
+		configuration, err := Load([]string{"-config", path, "-reactions-per-second", "10"}, io.Discard)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "postgres://file/presentations", configuration.DatabaseURL)
+		assert.Equal(t, ":9001", configuration.ListenAddress)
+		assert.Equal(t, 10, configuration.ReactionsPerSecond)
+		assert.Equal(t, 2*time.Hour, configuration.JoinCodeTTL)
+		assert.Equal(t, []string{"https://slides.example.com", "https://audience.example.com"}, configuration.CORSAllowedOrigins)
+	})
+
+	t.Run("TOML File", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "service.toml", `
+database_url = "postgres://file/presentations"
+db_max_open_conns = 10
+http_write_timeout = "1m"
+enable_web_app = false
+`)
+		t.Setenv("CONFIG_FILE", path)
+
+		// Act
+		configuration, err := New()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 10, configuration.DBMaxOpenConns)
+		assert.Equal(t, time.Minute, configuration.WriteTimeout)
+		assert.False(t, configuration.WebApp)
+	})
+
+	t.Run("Invalid Configuration", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "service.yaml", "listen_adress: \":9000\"\nupstream_url: ftp://example.com\n")
+		t.Setenv("HTTP_READ_TIMEOUT", "soon")
+
+		// Act
+		_, err := Load([]string{"-config", path}, io.Discard)
+
+		// Assert
+		assert.EqualError(t, err, "invalid configuration:\n"+
+			"  - listen_adress in "+path+" is not a known setting\n"+
+			"  - HTTP_READ_TIMEOUT must be a duration such as 30s or 5m\n"+
+			"  - database_url is required, set DATABASE_URL\n"+
+			`  - upstream_url must be an http or https URL, got "ftp://example.com"`)
+	})
+
+	t.Run("Invalid Flag", func(t *testing.T) {
+		// Arrange
+		t.Setenv("DATABASE_URL", "postgres://localhost/presentations")
+
+		// Act
+		_, err := Load([]string{"-db-max-open-conns", "many"}, io.Discard)
+
+		// Assert
+		assert.EqualError(t, err, `invalid value "many" for flag -db-max-open-conns: must be an integer`)
+	})
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"interactive-presentation/src/models"
+	"interactive-presentation/src/storage"
+	"interactive-presentation/src/utilities"
+)
+
+const (
+	presenterTokenHeader = "X-Presenter-Token"
+)
+
+// RequirePresenter only lets requests through that carry the presenter token
+// issued for the presentation in the URL, either as a bearer token in the
+// Authorization header or in the X-Presenter-Token header.
+func RequirePresenter(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+		if err != nil {
+			utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+			return
+		}
+
+		token := presenterToken(r)
+		if token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			utilities.WriteProblem(w, r, utilities.ProblemPresenterTokenRequired, nil)
+			return
+		}
+
+		var tokens []models.PresenterTokenDB
+		err = storage.SelectFromTable(r.Context(), "presenter_token", presentationUUID, &tokens)
+		if err != nil {
+			utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from presenter_token table: %w", err))
+			return
+		}
+
+		tokenHash := utilities.HashToken(token)
+		for _, stored := range tokens {
+			if subtle.ConstantTimeCompare([]byte(stored.TokenHash), []byte(tokenHash)) == 1 {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresenterToken, nil)
+	})
+}
+
+func presenterToken(r *http.Request) string {
+	if token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); found {
+		return strings.TrimSpace(token)
+	}
+	return strings.TrimSpace(r.Header.Get(presenterTokenHeader))
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"interactive-presentation/src/models"
+	"interactive-presentation/src/moderation"
+	"interactive-presentation/src/storage"
+	"interactive-presentation/src/utilities"
+)
+
+func GetBlocklist(w http.ResponseWriter, r *http.Request) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
+
+	var entries []models.BlocklistDB
+	err = storage.SelectFromTable(r.Context(), "blocklist", presentationUUID, &entries)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from blocklist table: %w", err))
+		return
+	}
+
+	blocklist := models.Blocklist{Words: []string{}}
+	for _, entry := range entries {
+		blocklist.Words = append(blocklist.Words, entry.Word)
+	}
+
+	_ = utilities.WriteJSONResponse(w, blocklist)
+}
+
+func PutBlocklist(w http.ResponseWriter, r *http.Request) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
+
+	bodyBytes, err := utilities.ReadRequestBody(r)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
+		return
+	}
+
+	var blocklist models.Blocklist
+	if err = json.Unmarshal(bodyBytes, &blocklist); err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidRequestBody, err)
+		return
+	}
+
+	var presentations []models.PresentationDB
+	err = storage.SelectFromTable(r.Context(), "presentation", presentationUUID, &presentations)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from presentation table: %w", err))
+		return
+	}
+	if len(presentations) == 0 {
+		utilities.WriteProblem(w, r, utilities.ProblemPresentationNotFound, nil)
+		return
+	}
+
+	seen := make(map[string]bool)
+	words := []string{}
+	for _, word := range blocklist.Words {
+		word = moderation.NormalizeWord(word)
+		if word == "" || seen[word] {
+			continue
+		}
+		seen[word] = true
+		words = append(words, word)
+	}
+
+	if err = storage.ReplaceBlocklist(r.Context(), presentationUUID, words); err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error replacing blocklist: %w", err))
+		return
+	}
+
+	_ = utilities.WriteJSONResponse(w, models.Blocklist{Words: words})
+}
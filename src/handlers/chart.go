@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"interactive-presentation/src/charts"
+	"interactive-presentation/src/models"
+	"interactive-presentation/src/storage"
+	"interactive-presentation/src/utilities"
+)
+
+func GetPollChartSVG(w http.ResponseWriter, r *http.Request) {
+	writePollChart(w, r, "image/svg+xml", func(chart charts.Chart, kind charts.Kind) ([]byte, error) {
+		return charts.SVG(chart, kind), nil
+	})
+}
+
+func GetPollChartPNG(w http.ResponseWriter, r *http.Request) {
+	writePollChart(w, r, "image/png", charts.PNG)
+}
+
+func writePollChart(w http.ResponseWriter, r *http.Request, contentType string, render func(charts.Chart, charts.Kind) ([]byte, error)) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
+
+	pollUUID, err := utilities.ParseUUIDFromRequest(r, "poll_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPollID, err)
+		return
+	}
+
+	kind, err := charts.ParseKind(r.URL.Query().Get("type"))
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidParameter.WithDetail("Type must be one of bar or pie"), err)
+		return
+	}
+
+	var polls []models.PollDB
+	err = storage.SelectFromTable(r.Context(), "poll", presentationUUID, &polls)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from poll table: %w", err))
+		return
+	}
+
+	for _, poll := range polls {
+		if poll.PollID != pollUUID {
+			continue
+		}
+
+		result, err := loadPollResults(r.Context(), poll)
+		if err != nil {
+			utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error loading poll results: %w", err))
+			return
+		}
+
+		chart := charts.Chart{Title: poll.Question}
+		for _, option := range result.Options {
+			chart.Items = append(chart.Items, charts.Item{Label: option.Value, Value: result.Count(option.Key)})
+		}
+
+		image, err := render(chart, kind)
+		if err != nil {
+			utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("failed to render chart: %w", err))
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		_, err = w.Write(image)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error writing chart", "error", err)
+		}
+		return
+	}
+
+	utilities.WriteProblem(w, r, utilities.ProblemPollNotFound, nil)
+}
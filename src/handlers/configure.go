@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"interactive-presentation/src/config"
+	"interactive-presentation/src/metrics"
+	"interactive-presentation/src/reactions"
+	"interactive-presentation/src/tracing"
+)
+
+// Settings of the handlers, replaced by Configure at startup.
+var (
+	upstreamURL    = config.DefaultUpstreamURL
+	upstreamClient = newUpstreamClient(config.Default().UpstreamTimeout)
+	publicURL      string
+	trustProxy     bool
+	allowedHosts   = config.Default().AllowedHosts
+	webApp         = config.Default().WebApp
+	joinCodeTTL    = config.Default().JoinCodeTTL
+	idempotencyTTL = config.Default().IdempotencyKeyTTL
+	// idempotencyLease is how long a request may hold its idempotency key
+	// before retries may take it over, a request cannot answer after the
+	// server's write timeout anyway.
+	idempotencyLease = config.Default().WriteTimeout
+	reactionHub      = reactions.NewHub(config.Default().ReactionsPerSecond)
+)
+
+// Configure applies the upstream, join code, idempotency, rate limit and web
+// app settings of the configuration. It must be called before the handlers serve
+// requests.
+func Configure(configuration *config.Config) {
+	upstreamURL = configuration.UpstreamURL
+	upstreamClient = newUpstreamClient(configuration.UpstreamTimeout)
+	publicURL = configuration.PublicURL
+	trustProxy = configuration.TrustProxyHeaders
+	allowedHosts = configuration.AllowedHosts
+	webApp = configuration.WebApp
+	joinCodeTTL = configuration.JoinCodeTTL
+	idempotencyTTL = configuration.IdempotencyKeyTTL
+	idempotencyLease = configuration.WriteTimeout
+	reactionHub = reactions.NewHub(configuration.ReactionsPerSecond)
+}
+
+func newUpstreamClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: tracing.Transport(metrics.UpstreamTransport(http.DefaultTransport)),
+	}
+}
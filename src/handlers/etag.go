@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"interactive-presentation/src/models"
+	"interactive-presentation/src/storage"
+	"interactive-presentation/src/utilities"
+)
+
+// presentationETag returns a strong entity tag for the state of a presentation.
+// Every response derived from that state changes together with its version.
+func presentationETag(presentation models.PresentationDB) string {
+	return fmt.Sprintf(`"%s-%d"`, presentation.PresentationID, presentation.Version)
+}
+
+// pollETag returns a strong entity tag for the state of a presentation
+// together with the votes of one of its polls. Votes leave the version of the
+// presentation alone, so that they never queue on its row, and are tracked by
+// the ID of the latest one instead.
+func pollETag(presentation models.PresentationDB, latestVoteID int64) string {
+	return fmt.Sprintf(`"%s-%d-%d"`, presentation.PresentationID, presentation.Version, latestVoteID)
+}
+
+// pollNotModified is notModified for a response carrying the votes of the
+// poll at index.
+func pollNotModified(w http.ResponseWriter, r *http.Request, presentation models.PresentationDB, index int) bool {
+	latestVoteID, err := storage.LatestVoteID(r.Context(), presentation.PresentationID, index)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
+		return true
+	}
+	return notModified(w, r, pollETag(presentation, latestVoteID))
+}
+
+// notModified sets the ETag and Cache-Control headers of a response. When the
+// If-None-Match header of the request matches the ETag it answers with 304 Not
+// Modified, reporting whether the handler is done.
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, no-cache")
+
+	if !matchesETag(r.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// matchesETag reports whether an If-None-Match header lists the ETag. As
+// required for If-None-Match, weak tags are compared by their opaque value.
+func matchesETag(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"interactive-presentation/src/models"
+)
+
+func TestMatchesETag(t *testing.T) {
+	etag := `"6f1c-3"`
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		headers := []string{
+			`"6f1c-3"`,
+			`W/"6f1c-3"`,
+			`"6f1c-2", "6f1c-3"`,
+			`"6f1c-2",W/"6f1c-3"`,
+			`*`,
+		}
+
+		for _, header := range headers {
+			// Act
+			matches := matchesETag(header, etag)
+
+			// Assert
+			assert.True(t, matches, "If-None-Match %s", header)
+		}
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		// Arrange
+		headers := []string{"", `"6f1c-2"`, `"6f1c-2", W/"6f1c-4"`, `6f1c-3`}
+
+		for _, header := range headers {
+			// Act
+			matches := matchesETag(header, etag)
+
+			// Assert
+			assert.False(t, matches, "If-None-Match %s", header)
+		}
+	})
+}
+
+func TestNotModified(t *testing.T) {
+	presentation := models.PresentationDB{PresentationID: uuid.New(), Version: 3}
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		r := newRequest(http.MethodGet, "/", "")
+		r.Header.Set("If-None-Match", presentationETag(presentation))
+
+		// Act
+		done := notModified(w, r, presentationETag(presentation))
+
+		// Assert
+		assert.True(t, done)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Equal(t, presentationETag(presentation), w.Header().Get("ETag"))
+		assert.Equal(t, "public, no-cache", w.Header().Get("Cache-Control"))
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("Changed Presentation", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		r := newRequest(http.MethodGet, "/", "")
+		r.Header.Set("If-None-Match", presentationETag(models.PresentationDB{PresentationID: presentation.PresentationID, Version: 2}))
+
+		// Act
+		done := notModified(w, r, presentationETag(presentation))
+
+		// Assert
+		assert.False(t, done)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"`+presentation.PresentationID.String()+`-3"`, w.Header().Get("ETag"))
+		assert.Equal(t, "public, no-cache", w.Header().Get("Cache-Control"))
+	})
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"interactive-presentation/src/models"
+	"interactive-presentation/src/storage"
+	"interactive-presentation/src/utilities"
+	"interactive-presentation/src/xlsx"
+)
+
+const (
+	// exportFlushInterval is the number of rows written between flushes of
+	// a streamed export.
+	exportFlushInterval = 100
+)
+
+func ExportPresentation(w http.ResponseWriter, r *http.Request) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "jsonl" && format != "xlsx" {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidParameter.WithDetail("Format must be one of csv, jsonl or xlsx"), nil)
+		return
+	}
+
+	var presentations []models.PresentationDB
+	err = storage.SelectFromTable(r.Context(), "presentation", presentationUUID, &presentations)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from presentation table: %w", err))
+		return
+	}
+	if len(presentations) == 0 {
+		utilities.WriteProblem(w, r, utilities.ProblemPresentationNotFound, nil)
+		return
+	}
+
+	switch format {
+	case "csv":
+		exportCSV(w, r, presentationUUID)
+	case "jsonl":
+		exportJSONLines(w, r, presentationUUID)
+	case "xlsx":
+		exportWorkbook(w, r, presentationUUID)
+	}
+}
+
+func exportCSV(w http.ResponseWriter, r *http.Request, presentationUUID uuid.UUID) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="presentation-%s.csv"`, presentationUUID))
+
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"poll_index", "poll_id", "question", "key", "value", "client_id"})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "error writing export", "error", err)
+		return
+	}
+
+	rows := 0
+	err = storage.ForEachVote(r.Context(), presentationUUID, func(vote models.VoteExport) error {
+		err := writer.Write([]string{
+			strconv.Itoa(vote.PollIndex), vote.PollID.String(),
+			csvCell(vote.Question), csvCell(vote.Key), csvCell(vote.Value), csvCell(vote.ClientID),
+		})
+		if err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushInterval == 0 {
+			writer.Flush()
+			flush(w)
+		}
+		return writer.Error()
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "error exporting votes", "error", err)
+	}
+
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		slog.ErrorContext(r.Context(), "error writing export", "error", err)
+	}
+}
+
+// csvCell keeps spreadsheet applications from evaluating text supplied by
+// clients as a formula by prefixing it with a quote.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func exportJSONLines(w http.ResponseWriter, r *http.Request, presentationUUID uuid.UUID) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="presentation-%s.jsonl"`, presentationUUID))
+
+	encoder := json.NewEncoder(w)
+	rows := 0
+	err := storage.ForEachVote(r.Context(), presentationUUID, func(vote models.VoteExport) error {
+		if err := encoder.Encode(vote); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushInterval == 0 {
+			flush(w)
+		}
+		return nil
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "error exporting votes", "error", err)
+	}
+}
+
+func flush(w http.ResponseWriter) {
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// exportWorkbook writes a workbook with a summary sheet holding one row per
+// poll with the vote count of every option, followed by one sheet per poll
+// with its raw votes.
+func exportWorkbook(w http.ResponseWriter, r *http.Request, presentationUUID uuid.UUID) {
+	results, err := loadPresentationResults(r.Context(), presentationUUID)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error loading presentation results: %w", err))
+		return
+	}
+
+	workbook := xlsx.New()
+	summary := workbook.AddSheet("Summary")
+	maxOptions := 0
+	for _, result := range results {
+		maxOptions = max(maxOptions, len(result.Options))
+	}
+	header := []interface{}{"Poll", "Question", "Total votes"}
+	for i := 1; i <= maxOptions; i++ {
+		header = append(header, fmt.Sprintf("Option %d", i), "Votes")
+	}
+	summary.AddHeader(header...)
+
+	for _, result := range results {
+		row := []interface{}{result.Poll.Index + 1, result.Poll.Question, len(result.Votes)}
+		for _, option := range result.Options {
+			row = append(row, option.Key+": "+option.Value, result.Count(option.Key))
+		}
+		summary.AddRow(row...)
+
+		values := make(map[string]string)
+		for _, option := range result.Options {
+			values[option.Key] = option.Value
+		}
+		sheet := workbook.AddSheet(fmt.Sprintf("Poll %d", result.Poll.Index+1))
+		sheet.AddRow("Question", result.Poll.Question)
+		sheet.AddRow()
+		sheet.AddHeader("Key", "Value", "Client ID")
+		for _, vote := range result.Votes {
+			sheet.AddRow(vote.Key, values[vote.Key], vote.ClientID)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="presentation-%s.xlsx"`, presentationUUID))
+	if err = workbook.Write(w); err != nil {
+		slog.ErrorContext(r.Context(), "error writing workbook", "error", err)
+	}
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"interactive-presentation/src/models"
+)
+
+func TestExportPresentation(t *testing.T) {
+	pattern := "/presentations/{presentation_id}/export"
+	presentation := models.PresentationDB{PresentationID: uuid.New(), Version: 1}
+	pollID := uuid.New()
+	target := fmt.Sprintf("/presentations/%s/export", presentation.PresentationID)
+	voteRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"index", "poll_id", "question", "key", "value", "client_id", "vote_id", "created_at"}).
+			AddRow(0, pollID, "Pets?", "a", "Cats", "client-1", 1, time.Now()).
+			AddRow(0, pollID, "Pets?", "b", "Dogs", "=HYPERLINK(\"http://evil\")", 2, time.Now())
+	}
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM presentation").WillReturnRows(presentationRows(presentation))
+		mock.ExpectQuery("SELECT poll.index").WithArgs(presentation.PresentationID).WillReturnRows(voteRows())
+
+		// Act
+		w := serve(ExportPresentation, pattern, newRequest(http.MethodGet, target, ""))
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, strings.Join([]string{
+			"poll_index,poll_id,question,key,value,client_id",
+			fmt.Sprintf("0,%s,Pets?,a,Cats,client-1", pollID),
+			fmt.Sprintf(`0,%s,Pets?,b,Dogs,"'=HYPERLINK(""http://evil"")"`, pollID),
+		}, "\n")+"\n", w.Body.String())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("JSON Lines", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM presentation").WillReturnRows(presentationRows(presentation))
+		mock.ExpectQuery("SELECT poll.index").WithArgs(presentation.PresentationID).WillReturnRows(voteRows())
+
+		// Act
+		w := serve(ExportPresentation, pattern, newRequest(http.MethodGet, target+"?format=jsonl", ""))
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+		assert.Len(t, lines, 2)
+		var vote map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &vote))
+		assert.Equal(t, map[string]interface{}{
+			"poll_index": float64(0), "poll_id": pollID.String(), "question": "Pets?", "key": "a", "value": "Cats", "client_id": "client-1",
+		}, vote)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Format", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+
+		// Act
+		w := serve(ExportPresentation, pattern, newRequest(http.MethodGet, target+"?format=pdf", ""))
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "invalid_parameter", problemCode(t, w))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCSVCell(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		cells := map[string]string{
+			"Cats":        "Cats",
+			"":            "",
+			"=1+1":        "'=1+1",
+			"+1":          "'+1",
+			"-1":          "'-1",
+			"@SUM(A1:A2)": "'@SUM(A1:A2)",
+			"a=b":         "a=b",
+		}
+
+		for value, expected := range cells {
+			// Act
+			cell := csvCell(value)
+
+			// Assert
+			assert.Equal(t, expected, cell, "value %q", value)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-chi/chi/v5"
+
+	"interactive-presentation/src/models"
+	"interactive-presentation/src/storage"
+	"interactive-presentation/src/utilities"
+)
+
+// mockDB replaces the connection pool of the storage package with a stub for
+// the duration of the test.
+func mockDB(t *testing.T) sqlmock.Sqlmock {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage.SetDB(db)
+	t.Cleanup(func() {
+		_ = storage.Close()
+	})
+	return mock
+}
+
+// serve routes a request through handler registered under the chi pattern so
+// URL parameters are set as in the service.
+func serve(handler http.HandlerFunc, pattern string, r *http.Request) *httptest.ResponseRecorder {
+	router := chi.NewRouter()
+	router.Method(r.Method, pattern, handler)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	return w
+}
+
+func newRequest(method string, target string, body string) *http.Request {
+	return httptest.NewRequest(method, target, strings.NewReader(body))
+}
+
+func pollRows(polls ...models.PollDB) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"poll_id", "question", "presentation_id", "index", "closed"})
+	for _, poll := range polls {
+		rows.AddRow(poll.PollID, poll.Question, poll.PresentationID, poll.Index, poll.Closed)
+	}
+	return rows
+}
+
+func optionRows(options ...models.OptionDB) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"key", "value", "poll_id", "index"})
+	for _, option := range options {
+		rows.AddRow(option.Key, option.Value, option.PollID, option.Index)
+	}
+	return rows
+}
+
+func problemCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	var problem utilities.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("response is no problem: %s", w.Body.String())
+	}
+	return problem.Code
+}
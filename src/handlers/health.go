@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"interactive-presentation/src/health"
+	"interactive-presentation/src/storage"
+)
+
+// readinessTimeout bounds each dependency check of the readiness probe.
+const readinessTimeout = 2 * time.Second
+
+// GetHealthz reports that the process is alive without checking any
+// dependency, so a database outage does not get the service restarted.
+func GetHealthz(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, r, health.Report{Status: health.StatusOK})
+}
+
+// GetReadyz checks the database, its schema version and the upstream
+// presentation service and answers 503 unless the database and schema are
+// available. The upstream check is informational only: every instance shares
+// the upstream, so failing on it would take the whole deployment out of
+// rotation at once.
+func GetReadyz(w http.ResponseWriter, r *http.Request) {
+	report := health.Run(r.Context(), readinessTimeout,
+		health.Check{Name: "database", Run: storage.Ping},
+		health.Check{Name: "migrations", Run: checkSchemaVersion},
+		health.Check{Name: "upstream", Run: checkUpstream, Informational: true},
+	)
+	for name, result := range report.Checks {
+		if result.Error != nil {
+			slog.WarnContext(r.Context(), "readiness check failed", "check", name, "status", result.Status, "error", result.Error)
+		}
+	}
+	writeHealthReport(w, r, report)
+}
+
+func writeHealthReport(w http.ResponseWriter, r *http.Request, report health.Report) {
+	status := http.StatusOK
+	if report.Status != health.StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		slog.ErrorContext(r.Context(), "error writing health report", "error", err)
+	}
+}
+
+// checkSchemaVersion fails while the schema is behind the version this build
+// expects. Newer schemas pass, as during a rolling deploy the first new
+// instance migrates the database while older ones keep serving.
+func checkSchemaVersion(ctx context.Context) error {
+	version, err := storage.AppliedSchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if version < storage.SchemaVersion() {
+		return fmt.Errorf("schema version is %d, expected at least %d", version, storage.SchemaVersion())
+	}
+	return nil
+}
+
+// checkUpstream treats any answer below 500 as reachable, the upstream has no
+// dedicated health endpoint.
+func checkUpstream(ctx context.Context) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, upstreamURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := upstreamClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("upstream answered %s", resp.Status)
+	}
+	return nil
+}
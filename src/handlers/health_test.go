@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"interactive-presentation/src/storage"
+)
+
+func TestCheckSchemaVersion(t *testing.T) {
+	schemaVersion := func(mock sqlmock.Sqlmock, version int) {
+		mock.ExpectQuery("SELECT COALESCE\\(MAX\\(version\\), 0\\) FROM schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(version))
+	}
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		schemaVersion(mock, storage.SchemaVersion())
+
+		// Act
+		err := checkSchemaVersion(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Newer Schema", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		schemaVersion(mock, storage.SchemaVersion()+1)
+
+		// Act
+		err := checkSchemaVersion(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Schema Behind", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		schemaVersion(mock, storage.SchemaVersion()-1)
+
+		// Act
+		err := checkSchemaVersion(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"interactive-presentation/src/storage"
+	"interactive-presentation/src/utilities"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader marks responses replayed for a retry.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+	// redactedToken replaces presenter tokens in stored responses.
+	redactedToken = "redacted"
+)
+
+// Idempotent makes POST requests with an Idempotency-Key header safe to retry.
+// The first response to a key is stored and replayed for retries with the
+// same query and payload. Retries with a different query or payload, or while
+// the first request is still in progress, are rejected. Temporary failures are not stored so the
+// request can be retried. The key of a request that never finishes, e.g.
+// because the process died, is freed again after the write timeout. Presenter
+// tokens are only ever sent once, replays carry a placeholder instead.
+func Idempotent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			utilities.WriteProblem(w, r, utilities.ProblemInvalidIdempotencyKey.WithDetail("Keys are limited to %d characters", maxIdempotencyKeyLength), nil)
+			return
+		}
+
+		bodyBytes, err := utilities.ReadRequestBody(r)
+		if err != nil {
+			utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		requestHash := utilities.HashToken(r.URL.RawQuery + "\n" + string(bodyBytes))
+		scope := idempotencyScope(r.URL.Path)
+
+		stored, reserved, err := storage.ReserveIdempotencyKey(r.Context(), key, scope, requestHash, idempotencyTTL, idempotencyLease)
+		if err != nil {
+			utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error reserving idempotency key: %w", err))
+			return
+		}
+		if !reserved {
+			switch {
+			case stored.RequestHash != requestHash:
+				utilities.WriteProblem(w, r, utilities.ProblemIdempotencyKeyReused, nil)
+			case stored.Status == 0:
+				w.Header().Set("Retry-After", "1")
+				utilities.WriteProblem(w, r, utilities.ProblemIdempotencyInProgress, nil)
+			default:
+				if stored.ContentType != "" {
+					w.Header().Set("Content-Type", stored.ContentType)
+				}
+				w.Header().Set(idempotentReplayedHeader, "true")
+				w.WriteHeader(stored.Status)
+				_, _ = w.Write(stored.Body)
+			}
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		// The outcome is stored even when the client went away meanwhile, a
+		// retry is exactly what the key is for.
+		ctx := context.WithoutCancel(r.Context())
+		if retryable(recorder.status, w.Header()) {
+			err = storage.ReleaseIdempotencyKey(ctx, key, scope)
+		} else {
+			err = storage.CompleteIdempotencyKey(ctx, key, scope, recorder.status, w.Header().Get("Content-Type"), redactTokens(recorder.body.Bytes()))
+		}
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error storing idempotent response", "error", err)
+		}
+	})
+}
+
+// idempotencyScope returns the path a key is scoped to. Every API version runs
+// the same POST handlers, so the version prefix is left out.
+func idempotencyScope(path string) string {
+	for _, version := range []string{"/v1", "/v2"} {
+		if strings.HasPrefix(path, version+"/") {
+			return strings.TrimPrefix(path, version)
+		}
+	}
+	return path
+}
+
+// retryable reports whether a response is a temporary failure that a retry
+// with the same key may get past: a server error, rate limiting, a conflict
+// with the current state or any response asking to retry later.
+func retryable(status int, header http.Header) bool {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return true
+	case status == http.StatusRequestTimeout, status == http.StatusConflict, status == http.StatusTooManyRequests:
+		return true
+	default:
+		return header.Get("Retry-After") != ""
+	}
+}
+
+// redactTokens replaces the presenter token of a JSON response body so the
+// replay store never holds one in plaintext. Other bodies are kept as they
+// are.
+func redactTokens(body []byte) []byte {
+	var result map[string]json.RawMessage
+	if err := json.Unmarshal(body, &result); err != nil {
+		return body
+	}
+	if _, ok := result["presenter_token"]; !ok {
+		return body
+	}
+
+	result["presenter_token"], _ = json.Marshal(redactedToken)
+	redacted, err := json.Marshal(result)
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+// responseRecorder passes a response through while keeping its status and
+// body.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"interactive-presentation/src/utilities"
+)
+
+func TestIdempotencyScope(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		paths := []string{"/presentations", "/v1/presentations", "/v2/presentations"}
+
+		for _, path := range paths {
+			// Act
+			scope := idempotencyScope(path)
+
+			// Assert
+			assert.Equal(t, "/presentations", scope, "path %s", path)
+		}
+	})
+
+	t.Run("Unversioned Path", func(t *testing.T) {
+		// Act
+		scope := idempotencyScope("/v2")
+
+		// Assert
+		assert.Equal(t, "/v2", scope)
+	})
+}
+
+func TestRetryable(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		statuses := []int{http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway}
+
+		for _, status := range statuses {
+			// Act
+			retry := retryable(status, http.Header{})
+
+			// Assert
+			assert.True(t, retry, "status %d", status)
+		}
+	})
+
+	t.Run("Retry-After", func(t *testing.T) {
+		// Arrange
+		header := http.Header{}
+		header.Set("Retry-After", "5")
+
+		// Act
+		retry := retryable(http.StatusForbidden, header)
+
+		// Assert
+		assert.True(t, retry)
+	})
+
+	t.Run("Final Response", func(t *testing.T) {
+		// Arrange
+		statuses := []int{http.StatusOK, http.StatusCreated, http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity}
+
+		for _, status := range statuses {
+			// Act
+			retry := retryable(status, http.Header{})
+
+			// Assert
+			assert.False(t, retry, "status %d", status)
+		}
+	})
+}
+
+func TestIdempotent(t *testing.T) {
+	body := `{"polls":[]}`
+	columns := []string{"key", "path", "request_hash", "status", "content_type", "body", "created_at"}
+	post := func(handler http.Handler, body string) *httptest.ResponseRecorder {
+		r := newRequest(http.MethodPost, "/presentations", body)
+		r.Header.Set(idempotencyKeyHeader, "key-1")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+	expectStored := func(mock sqlmock.Sqlmock, hash string, status int, responseBody string) {
+		mock.ExpectExec("DELETE FROM idempotency_key WHERE created_at").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO idempotency_key").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT key, path, request_hash").WithArgs("key-1", "/presentations").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("key-1", "/presentations", hash, status, "application/json", []byte(responseBody), time.Now()))
+	}
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		calls := 0
+		handler := Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"presentation_id":"1"}`))
+		}))
+		mock.ExpectExec("DELETE FROM idempotency_key WHERE created_at").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO idempotency_key").WithArgs("key-1", "/presentations", utilities.HashToken("\n"+body), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE idempotency_key SET status").
+			WithArgs(http.StatusCreated, "application/json", []byte(`{"presentation_id":"1"}`), "key-1", "/presentations").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectStored(mock, utilities.HashToken("\n"+body), http.StatusCreated, `{"presentation_id":"1"}`)
+
+		// Act
+		first := post(handler, body)
+		retry := post(handler, body)
+
+		// Assert
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Empty(t, first.Header().Get(idempotentReplayedHeader))
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, "true", retry.Header().Get(idempotentReplayedHeader))
+		assert.Equal(t, "application/json", retry.Header().Get("Content-Type"))
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Presenter Token Redacted", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		handler := Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"presentation_id":"1","presenter_token":"secret"}`))
+		}))
+		mock.ExpectExec("DELETE FROM idempotency_key WHERE created_at").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO idempotency_key").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE idempotency_key SET status").
+			WithArgs(http.StatusCreated, "application/json", []byte(`{"presentation_id":"1","presenter_token":"redacted"}`), "key-1", "/presentations").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// Act
+		w := post(handler, body)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"presenter_token":"secret"`)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Different Payload", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		handler := Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("handler called for a reused key")
+		}))
+		expectStored(mock, utilities.HashToken("\n"+`{"polls":[{}]}`), http.StatusCreated, `{}`)
+
+		// Act
+		w := post(handler, body)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, "idempotency_key_reused", problemCode(t, w))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Different Query", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		handler := Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("handler called for a reused key")
+		}))
+		mock.ExpectExec("DELETE FROM idempotency_key WHERE created_at").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO idempotency_key").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT key, path, request_hash").WithArgs("key-1", "/presentations/import").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("key-1", "/presentations/import", utilities.HashToken("format=yaml\n"+body), http.StatusCreated, "application/json", []byte(`{}`), time.Now()))
+		r := newRequest(http.MethodPost, "/presentations/import?format=md", body)
+		r.Header.Set(idempotencyKeyHeader, "key-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, r)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, "idempotency_key_reused", problemCode(t, w))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("In Progress", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		handler := Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("handler called while the first request is in progress")
+		}))
+		expectStored(mock, utilities.HashToken("\n"+body), 0, "")
+
+		// Act
+		w := post(handler, body)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "idempotency_key_in_progress", problemCode(t, w))
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Server Error Releases Key", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		handler := Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			utilities.WriteProblem(w, r, utilities.ProblemInternal, nil)
+		}))
+		mock.ExpectExec("DELETE FROM idempotency_key WHERE created_at").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO idempotency_key").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM idempotency_key WHERE key = \\$1 AND path = \\$2").WithArgs("key-1", "/presentations").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// Act
+		w := post(handler, body)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rate Limit Releases Key", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		handler := Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "1")
+			utilities.WriteProblem(w, r, utilities.ProblemRateLimited, nil)
+		}))
+		mock.ExpectExec("DELETE FROM idempotency_key WHERE created_at").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO idempotency_key").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM idempotency_key WHERE key = \\$1 AND path = \\$2").WithArgs("key-1", "/presentations").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// Act
+		w := post(handler, body)
+
+		// Assert
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"interactive-presentation/src/models"
+	"interactive-presentation/src/storage"
+	"interactive-presentation/src/utilities"
+)
+
+const (
+	joinCodeLength   = 6
+	joinCodeAttempts = 10
+)
+
+// issueJoinCode replaces the join code of a presentation with a freshly
+// generated one, retrying with a new code whenever it collides with a code
+// that is still in use. The old code is only removed once the new one is
+// stored, so a presentation is never left without a join code.
+func issueJoinCode(ctx context.Context, presentationUUID uuid.UUID) (models.JoinCode, error) {
+	if err := storage.DeleteExpiredJoinCodes(ctx); err != nil {
+		return models.JoinCode{}, fmt.Errorf("error deleting expired join codes: %v", err)
+	}
+
+	for attempt := 0; attempt < joinCodeAttempts; attempt++ {
+		code, err := utilities.GenerateJoinCode(joinCodeLength)
+		if err != nil {
+			return models.JoinCode{}, err
+		}
+
+		joinCode := models.JoinCode{Code: code, PresentationID: presentationUUID, ExpiresAt: time.Now().UTC().Add(joinCodeTTL)}
+		err = storage.InsertIntoDatabase(ctx, "join_code", joinCode)
+		if storage.IsUniqueViolation(err) {
+			continue
+		}
+		if err != nil {
+			return models.JoinCode{}, err
+		}
+
+		if err = storage.DeleteOtherJoinCodes(ctx, presentationUUID, code); err != nil {
+			return models.JoinCode{}, err
+		}
+		return joinCode, nil
+	}
+
+	return models.JoinCode{}, errors.New("no unused join code found")
+}
+
+// ResolveJoinCode answers with the presentation a join code belongs to.
+// Browsers following the join URL of a valid code, e.g. from a QR code, are
+// redirected to the audience page instead when the web app is served.
+func ResolveJoinCode(w http.ResponseWriter, r *http.Request) {
+	code := utilities.NormalizeJoinCode(chi.URLParam(r, "code"))
+
+	var joinCodes []models.JoinCode
+	err := storage.SelectJoinCode(r.Context(), code, &joinCodes)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from join_code table: %w", err))
+		return
+	}
+	if len(joinCodes) == 0 {
+		utilities.WriteProblem(w, r, utilities.ProblemJoinCodeNotFound, nil)
+		return
+	}
+
+	if webApp && strings.Contains(r.Header.Get("Accept"), "text/html") {
+		http.Redirect(w, r, "/?code="+url.QueryEscape(joinCodes[0].Code), http.StatusFound)
+		return
+	}
+
+	_ = utilities.WriteJSONResponse(w, joinCodes[0])
+}
+
+func GetJoinCode(w http.ResponseWriter, r *http.Request) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
+
+	joinCode, ok := currentJoinCode(w, r, presentationUUID)
+	if !ok {
+		return
+	}
+
+	_ = utilities.WriteJSONResponse(w, joinCode)
+}
+
+// currentJoinCode loads the join code currently handed out for a
+// presentation. It answers the request itself when there is none, reporting
+// whether the handler may go on.
+func currentJoinCode(w http.ResponseWriter, r *http.Request, presentationUUID uuid.UUID) (models.JoinCode, bool) {
+	var joinCodes []models.JoinCode
+	err := storage.SelectCurrentJoinCode(r.Context(), presentationUUID, &joinCodes)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from join_code table: %w", err))
+		return models.JoinCode{}, false
+	}
+	if len(joinCodes) == 0 {
+		utilities.WriteProblem(w, r, utilities.ProblemJoinCodeNotFound, nil)
+		return models.JoinCode{}, false
+	}
+
+	return joinCodes[0], true
+}
+
+func PostJoinCode(w http.ResponseWriter, r *http.Request) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
+
+	joinCode, err := issueJoinCode(r.Context(), presentationUUID)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error issuing join code: %w", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = utilities.WriteJSONResponse(w, joinCode)
+}
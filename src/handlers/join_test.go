@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostJoinCode(t *testing.T) {
+	pattern := "/presentations/{presentation_id}/join-code"
+	presentationID := uuid.New()
+	target := fmt.Sprintf("/presentations/%s/join-code", presentationID)
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectExec("DELETE FROM join_code WHERE expires_at").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO join_code").WillReturnError(&pq.Error{Code: "23505"})
+		mock.ExpectExec("INSERT INTO join_code").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM join_code WHERE presentation_id").WithArgs(presentationID, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// Act
+		w := serve(PostJoinCode, pattern, newRequest(http.MethodPost, target, ""))
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Every Code Collides", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectExec("DELETE FROM join_code WHERE expires_at").WillReturnResult(sqlmock.NewResult(0, 0))
+		for attempt := 0; attempt < joinCodeAttempts; attempt++ {
+			mock.ExpectExec("INSERT INTO join_code").WillReturnError(&pq.Error{Code: "23505"})
+		}
+
+		// Act
+		w := serve(PostJoinCode, pattern, newRequest(http.MethodPost, target, ""))
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetJoinCode(t *testing.T) {
+	pattern := "/presentations/{presentation_id}/join-code"
+	presentationID := uuid.New()
+	target := fmt.Sprintf("/presentations/%s/join-code", presentationID)
+	columns := []string{"code", "presentation_id", "expires_at"}
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM join_code WHERE presentation_id=\\$1 AND expires_at > now\\(\\) ORDER BY expires_at DESC LIMIT 1").
+			WithArgs(presentationID).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("NEW123", presentationID, time.Now().Add(time.Hour)))
+
+		// Act
+		w := serve(GetJoinCode, pattern, newRequest(http.MethodGet, target, ""))
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"NEW123"`)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Valid Code", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM join_code").WithArgs(presentationID).WillReturnRows(sqlmock.NewRows(columns))
+
+		// Act
+		w := serve(GetJoinCode, pattern, newRequest(http.MethodGet, target, ""))
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "join_code_not_found", problemCode(t, w))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestResolveJoinCode(t *testing.T) {
+	pattern := "/join/{code}"
+	columns := []string{"code", "presentation_id", "expires_at"}
+	resolve := func(accept string) *httptest.ResponseRecorder {
+		r := newRequest(http.MethodGet, "/join/abc123", "")
+		r.Header.Set("Accept", accept)
+		return serve(ResolveJoinCode, pattern, r)
+	}
+	setWebApp := func(t *testing.T, enabled bool) {
+		previous := webApp
+		webApp = enabled
+		t.Cleanup(func() {
+			webApp = previous
+		})
+	}
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM join_code WHERE code").WithArgs("ABC123").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("ABC123", uuid.New(), time.Now().Add(time.Hour)))
+
+		// Act
+		w := resolve("application/json")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"ABC123"`)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Browser", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		setWebApp(t, true)
+		mock.ExpectQuery("SELECT \\* FROM join_code WHERE code").WithArgs("ABC123").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("ABC123", uuid.New(), time.Now().Add(time.Hour)))
+
+		// Act
+		w := resolve("text/html,application/xhtml+xml")
+
+		// Assert
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "/?code=ABC123", w.Header().Get("Location"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Browser Without Web App", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		setWebApp(t, false)
+		mock.ExpectQuery("SELECT \\* FROM join_code WHERE code").WithArgs("ABC123").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("ABC123", uuid.New(), time.Now().Add(time.Hour)))
+
+		// Act
+		w := resolve("text/html")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Browser With Unknown Code", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		setWebApp(t, true)
+		mock.ExpectQuery("SELECT \\* FROM join_code WHERE code").WithArgs("ABC123").WillReturnRows(sqlmock.NewRows(columns))
+
+		// Act
+		w := resolve("text/html")
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "join_code_not_found", problemCode(t, w))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -1,11 +1,17 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"sort"
 	"sync"
+	"time"
+
+	"github.com/google/uuid"
 
 	"interactive-presentation/src/models"
 	"interactive-presentation/src/storage"
@@ -15,29 +21,28 @@ import (
 func GetCurrentPoll(w http.ResponseWriter, r *http.Request) {
 	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
 	if err != nil {
-		log.Println(err)
-		http.Error(w, "Invalid presentation ID", http.StatusBadRequest)
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
 		return
 	}
 
 	var presentations []models.PresentationDB
-	err = storage.SelectFromTable("presentation", presentationUUID, &presentations)
+	err = storage.SelectFromTable(r.Context(), "presentation", presentationUUID, &presentations)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Sprintf("Error selecting from presentation table: %v", err), http.StatusInternalServerError)
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from presentation table: %w", err))
 		return
 	}
 	if len(presentations) == 0 {
-		log.Println("No presentation found")
-		http.Error(w, "No presentation found", http.StatusNotFound)
+		utilities.WriteProblem(w, r, utilities.ProblemPresentationNotFound, nil)
+		return
+	}
+	if notModified(w, r, presentationETag(presentations[0])) {
 		return
 	}
 
 	var polls []models.PollDB
-	err = storage.SelectFromTable("poll", presentationUUID, &polls)
+	err = storage.SelectFromTable(r.Context(), "poll", presentationUUID, &polls)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Sprintf("Error selecting from poll table: %v", err), http.StatusInternalServerError)
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from poll table: %w", err))
 		return
 	}
 
@@ -45,10 +50,9 @@ func GetCurrentPoll(w http.ResponseWriter, r *http.Request) {
 	for _, poll := range polls {
 		if presentations[0].CurrentPollIndex == poll.Index {
 			var optionsDB []models.OptionDB
-			err = storage.SelectFromTable("option", poll.PollID, &optionsDB)
+			err = storage.SelectFromTable(r.Context(), "option", poll.PollID, &optionsDB)
 			if err != nil {
-				log.Println(err)
-				http.Error(w, fmt.Sprintf("Error selecting from option table: %v", err), http.StatusInternalServerError)
+				utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from option table: %w", err))
 				return
 			}
 			sort.Slice(optionsDB, func(i, j int) bool {
@@ -67,77 +71,231 @@ func GetCurrentPoll(w http.ResponseWriter, r *http.Request) {
 }
 
 func PutCurrentPoll(w http.ResponseWriter, r *http.Request) {
-	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	presentationUUID, nextPollIndex, ok := moveCurrentPoll(w, r)
+	if !ok {
+		return
+	}
+
+	var polls []models.PollDB
+	err := storage.SelectFromTable(r.Context(), "poll", presentationUUID, &polls)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, "Invalid presentation ID", http.StatusBadRequest)
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from poll table: %w", err))
 		return
 	}
 
+	var nextPoll models.Poll
+	for _, poll := range polls {
+		if poll.Index == nextPollIndex {
+			var optionsDB []models.OptionDB
+			err = storage.SelectFromTable(r.Context(), "option", poll.PollID, &optionsDB)
+			if err != nil {
+				utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from option table: %w", err))
+				return
+			}
+			sort.Slice(optionsDB, func(i, j int) bool {
+				return optionsDB[i].Index < optionsDB[j].Index
+			})
+			var options []models.Option
+			for _, option := range optionsDB {
+				options = append(options, models.Option{Key: option.Key, Value: option.Value})
+			}
+			nextPoll = models.Poll{PollID: poll.PollID, Question: poll.Question, Options: options}
+			break
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = utilities.WriteJSONResponse(w, nextPoll)
+}
+
+// moveCurrentPoll moves the presentation of the request to the next poll, or
+// the poll at the index in the body, and records when it was shown. It
+// answers the request itself when that fails, reporting whether the handler
+// may go on.
+func moveCurrentPoll(w http.ResponseWriter, r *http.Request) (uuid.UUID, int, bool) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return uuid.Nil, 0, false
+	}
+
 	var presentations []models.PresentationDB
-	err = storage.SelectFromTable("presentation", presentationUUID, &presentations)
+	err = storage.SelectFromTable(r.Context(), "presentation", presentationUUID, &presentations)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Sprintf("Error selecting from presentation table: %v", err), http.StatusInternalServerError)
-		return
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from presentation table: %w", err))
+		return uuid.Nil, 0, false
 	}
 	if len(presentations) == 0 {
-		log.Println("No presentation found")
-		http.Error(w, "No presentation found", http.StatusNotFound)
-		return
+		utilities.WriteProblem(w, r, utilities.ProblemPresentationNotFound, nil)
+		return uuid.Nil, 0, false
 	}
 
 	nextPollIndex := presentations[0].CurrentPollIndex + 1
 
+	bodyBytes, err := utilities.ReadRequestBody(r)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
+		return uuid.Nil, 0, false
+	}
+	if len(bytes.TrimSpace(bodyBytes)) > 0 {
+		var pollIndex models.PollIndex
+		if err = json.Unmarshal(bodyBytes, &pollIndex); err != nil || pollIndex.Index == nil || *pollIndex.Index < 0 {
+			utilities.WriteProblem(w, r, utilities.ProblemInvalidRequestBody, err)
+			return uuid.Nil, 0, false
+		}
+		nextPollIndex = *pollIndex.Index
+
+		// The index after the last poll ends the presentation, anything past it
+		// does not name a poll.
+		var polls []models.PollDB
+		err = storage.SelectFromTable(r.Context(), "poll", presentationUUID, &polls)
+		if err != nil {
+			utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from poll table: %w", err))
+			return uuid.Nil, 0, false
+		}
+		if nextPollIndex > len(polls) {
+			utilities.WriteProblem(w, r, utilities.ProblemInvalidRequestBody.WithDetail("Index must be at most %d", len(polls)), nil)
+			return uuid.Nil, 0, false
+		}
+	}
+
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		err = storage.UpdatePresentation(presentationUUID, nextPollIndex)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("Error updating presentation table: %v", err), http.StatusInternalServerError)
-			return
-		}
+		err = storage.UpdatePresentation(r.Context(), presentationUUID, nextPollIndex)
 	}()
 	wg.Wait()
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error updating presentation table: %w", err))
+		return uuid.Nil, 0, false
+	}
+
+	pollShownDB := models.PollShownDB{PresentationID: presentationUUID, PollIndex: nextPollIndex, ShownAt: time.Now().UTC()}
+	if err = storage.InsertIntoDatabase(r.Context(), "poll_shown", pollShownDB); err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error inserting into poll_shown database: %w", err))
+		return uuid.Nil, 0, false
+	}
+
+	return presentationUUID, nextPollIndex, true
+}
+
+func GetCurrentPollStatus(w http.ResponseWriter, r *http.Request) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
 
-	err = storage.SelectFromTable("presentation", presentationUUID, &presentations)
+	var presentations []models.PresentationDB
+	err = storage.SelectFromTable(r.Context(), "presentation", presentationUUID, &presentations)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Sprintf("Error selecting from presentation table: %v", err), http.StatusInternalServerError)
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from presentation table: %w", err))
+		return
+	}
+	if len(presentations) == 0 {
+		utilities.WriteProblem(w, r, utilities.ProblemPresentationNotFound, nil)
 		return
 	}
 
 	var polls []models.PollDB
-	err = storage.SelectFromTable("poll", presentationUUID, &polls)
+	err = storage.SelectFromTable(r.Context(), "poll", presentationUUID, &polls)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Sprintf("Error selecting from poll table: %v", err), http.StatusInternalServerError)
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from poll table: %w", err))
 		return
 	}
 
-	var nextPoll models.Poll
+	status := models.PollStatus{Index: presentations[0].CurrentPollIndex, Polls: len(polls), Status: models.PollClosed}
 	for _, poll := range polls {
-		if poll.Index == nextPollIndex {
-			var optionsDB []models.OptionDB
-			err = storage.SelectFromTable("option", poll.PollID, &optionsDB)
-			if err != nil {
-				log.Println(err)
-				http.Error(w, fmt.Sprintf("Error selecting from option table: %v", err), http.StatusInternalServerError)
-				return
-			}
-			sort.Slice(optionsDB, func(i, j int) bool {
-				return optionsDB[i].Index < optionsDB[j].Index
-			})
-			var options []models.Option
-			for _, option := range optionsDB {
-				options = append(options, models.Option{Key: option.Key, Value: option.Value})
-			}
-			nextPoll = models.Poll{PollID: poll.PollID, Question: poll.Question, Options: options}
-			break
+		if poll.Index == presentations[0].CurrentPollIndex {
+			status.PollID = poll.PollID
+			status.Status = pollStatus(poll)
 		}
 	}
 
-	w.WriteHeader(http.StatusOK)
-	_ = utilities.WriteJSONResponse(w, nextPoll)
+	_ = utilities.WriteJSONResponse(w, status)
+}
+
+func PutCurrentPollStatus(w http.ResponseWriter, r *http.Request) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
+
+	bodyBytes, err := utilities.ReadRequestBody(r)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
+		return
+	}
+
+	var status models.PollStatus
+	if err = json.Unmarshal(bodyBytes, &status); err != nil || (status.Status != models.PollOpen && status.Status != models.PollClosed) {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidRequestBody, err)
+		return
+	}
+
+	var presentations []models.PresentationDB
+	err = storage.SelectFromTable(r.Context(), "presentation", presentationUUID, &presentations)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from presentation table: %w", err))
+		return
+	}
+	if len(presentations) == 0 {
+		utilities.WriteProblem(w, r, utilities.ProblemPresentationNotFound, nil)
+		return
+	}
+
+	updated, err := storage.UpdatePollClosed(r.Context(), presentationUUID, presentations[0].CurrentPollIndex, status.Status == models.PollClosed)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error updating poll table: %w", err))
+		return
+	}
+	if updated == 0 {
+		utilities.WriteProblem(w, r, utilities.ProblemPollNotFound, nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func pollStatus(poll models.PollDB) string {
+	if poll.Closed {
+		return models.PollClosed
+	}
+	return models.PollOpen
+}
+
+var errPollNotFound = errors.New("poll not found")
+
+// presentationPoll reads the poll with the given ID. It returns
+// errPollNotFound unless the poll belongs to the presentation.
+func presentationPoll(ctx context.Context, presentationUUID uuid.UUID, pollUUID uuid.UUID) (models.PollDB, error) {
+	var polls []models.PollDB
+	err := storage.SelectFromTable(ctx, "poll", presentationUUID, &polls)
+	if err != nil {
+		return models.PollDB{}, fmt.Errorf("error selecting from poll table: %w", err)
+	}
+	for _, poll := range polls {
+		if poll.PollID == pollUUID {
+			return poll, nil
+		}
+	}
+	return models.PollDB{}, errPollNotFound
+}
+
+// currentPoll reads the current poll of the presentation. It returns
+// errPollNotFound when the presentation has moved past its last poll.
+func currentPoll(ctx context.Context, presentation models.PresentationDB) (models.PollDB, error) {
+	var polls []models.PollDB
+	err := storage.SelectFromTable(ctx, "poll", presentation.PresentationID, &polls)
+	if err != nil {
+		return models.PollDB{}, fmt.Errorf("error selecting from poll table: %w", err)
+	}
+	for _, poll := range polls {
+		if poll.Index == presentation.CurrentPollIndex {
+			return poll, nil
+		}
+	}
+	return models.PollDB{}, errPollNotFound
 }
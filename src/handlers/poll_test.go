@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"interactive-presentation/src/models"
+)
+
+func TestPutCurrentPoll(t *testing.T) {
+	pattern := "/presentations/{presentation_id}/polls/current"
+
+	t.Run("Index Past The End", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		presentation := models.PresentationDB{PresentationID: uuid.New(), Version: 1}
+		mock.ExpectQuery("SELECT \\* FROM presentation").WillReturnRows(presentationRows(presentation))
+		mock.ExpectQuery("SELECT \\* FROM poll").WillReturnRows(pollRows(
+			models.PollDB{PollID: uuid.New(), PresentationID: presentation.PresentationID, Index: 0},
+			models.PollDB{PollID: uuid.New(), PresentationID: presentation.PresentationID, Index: 1},
+		))
+		r := newRequest(http.MethodPut, fmt.Sprintf("/presentations/%s/polls/current", presentation.PresentationID), `{"index":3}`)
+
+		// Act
+		w := serve(PutCurrentPoll, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "invalid_request_body", problemCode(t, w))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -3,35 +3,56 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
+	"mime"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 
+	"interactive-presentation/src/importer"
 	"interactive-presentation/src/models"
 	"interactive-presentation/src/storage"
 	"interactive-presentation/src/utilities"
 )
 
-const (
-	baseURL = "https://infra.devskills.app/api/interactive-presentation/v4"
-)
-
 func CreatePresentation(w http.ResponseWriter, r *http.Request) {
 	bodyBytes, err := utilities.ReadRequestBody(r)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
 		return
 	}
 
-	url := baseURL + "/presentations"
-	resp, err := http.Post(url, "application/json", bytes.NewReader(bodyBytes))
+	createPresentation(w, r, bodyBytes)
+}
+
+// createPresentation validates the presentation in bodyBytes, forwards it to
+// the upstream service, stores its polls and options under the returned
+// presentation ID and writes the upstream response extended with the
+// presenter token and join code.
+func createPresentation(w http.ResponseWriter, r *http.Request, bodyBytes []byte) {
+	var presentation models.Presentation
+	if err := json.Unmarshal(bodyBytes, &presentation); err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidRequestBody, err)
+		return
+	}
+	if !validateRequestBody(w, r, presentation) {
+		return
+	}
+
+	url := upstreamURL + "/presentations"
+	request, err := http.NewRequestWithContext(r.Context(), http.MethodPost, url, bytes.NewReader(bodyBytes))
 	if err != nil {
-		log.Println(err)
-		http.Error(w, "Failed to create presentation", http.StatusInternalServerError)
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("failed to create presentation: %w", err))
+		return
+	}
+	request.Header.Set("Content-Type", "application/json")
+	resp, err := upstreamClient.Do(request)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemUpstreamFailed, fmt.Errorf("failed to create presentation: %w", err))
 		return
 	}
 	var bodyCloseError error
@@ -42,29 +63,27 @@ func CreatePresentation(w http.ResponseWriter, r *http.Request) {
 		}
 	}(resp.Body)
 	if err != nil {
-		log.Println("error closing response body", err)
+		slog.ErrorContext(r.Context(), "error closing response body", "error", err)
 	}
 
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentation, fmt.Errorf("upstream answered %s", resp.Status))
+		return
+	}
 	if resp.StatusCode != http.StatusCreated {
-		http.Error(w, "Failed to create presentation", resp.StatusCode)
+		utilities.WriteProblem(w, r, utilities.ProblemUpstreamFailed, fmt.Errorf("upstream answered %s", resp.Status))
 		return
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		http.Error(w, "Failed to read response", http.StatusInternalServerError)
+		utilities.WriteProblem(w, r, utilities.ProblemUpstreamFailed, fmt.Errorf("failed to read response: %w", err))
 		return
 	}
 
 	var result map[string]interface{}
 	if err = json.Unmarshal(body, &result); err != nil {
-		http.Error(w, "Failed to parse response", http.StatusInternalServerError)
-		return
-	}
-
-	var presentation models.Presentation
-	if err = json.Unmarshal(bodyBytes, &presentation); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		utilities.WriteProblem(w, r, utilities.ProblemUpstreamFailed, fmt.Errorf("failed to parse response: %w", err))
 		return
 	}
 
@@ -74,33 +93,110 @@ func CreatePresentation(w http.ResponseWriter, r *http.Request) {
 	if ok {
 		presentationDB.PresentationID = presentationUUID
 		presentationDB.CurrentPollIndex = 0
+		presentationDB.Version = 1
+	}
+
+	if err = storage.InsertIntoDatabase(r.Context(), "presentation", presentationDB); err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error inserting into presentation database: %w", err))
+		return
 	}
 
-	if err = storage.InsertIntoDatabase("presentation", presentationDB); err != nil {
-		http.Error(w, fmt.Sprintf("Error inserting into presentation database: %v", err), http.StatusInternalServerError)
+	pollShownDB := models.PollShownDB{PresentationID: presentationUUID, PollIndex: 0, ShownAt: time.Now().UTC()}
+	if err = storage.InsertIntoDatabase(r.Context(), "poll_shown", pollShownDB); err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error inserting into poll_shown database: %w", err))
 		return
 	}
 
 	for i, poll := range presentation.Polls {
 		pollID := uuid.New()
 		pollDB := models.PollDB{PollID: pollID, Question: poll.Question, PresentationID: presentationUUID, Index: i}
-		if err = storage.InsertIntoDatabase("poll", pollDB); err != nil {
-			http.Error(w, fmt.Sprintf("Error inserting into poll database: %v", err), http.StatusInternalServerError)
+		if err = storage.InsertIntoDatabase(r.Context(), "poll", pollDB); err != nil {
+			utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error inserting into poll database: %w", err))
 			return
 		}
 
 		for j, option := range poll.Options {
 			optionDB := models.OptionDB{Key: option.Key, Value: option.Value, PollID: pollID, Index: j}
-			if err = storage.InsertIntoDatabase("option", optionDB); err != nil {
-				http.Error(w, fmt.Sprintf("Error inserting into option database: %v", err), http.StatusInternalServerError)
+			if err = storage.InsertIntoDatabase(r.Context(), "option", optionDB); err != nil {
+				utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error inserting into option database: %w", err))
 				return
 			}
 		}
 	}
 
+	presenterToken, err := utilities.GenerateToken()
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("failed to generate presenter token: %w", err))
+		return
+	}
+	presenterTokenDB := models.PresenterTokenDB{PresentationID: presentationUUID, TokenHash: utilities.HashToken(presenterToken)}
+	if err = storage.InsertIntoDatabase(r.Context(), "presenter_token", presenterTokenDB); err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error inserting into presenter_token database: %w", err))
+		return
+	}
+	result["presenter_token"] = presenterToken
+
+	joinCode, err := issueJoinCode(r.Context(), presentationUUID)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error issuing join code: %w", err))
+		return
+	}
+	result["join_code"] = joinCode.Code
+
+	body, err = json.Marshal(result)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("failed to encode response: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(resp.StatusCode)
 	_, err = w.Write(body)
 	if err != nil {
-		log.Println(err)
+		slog.ErrorContext(r.Context(), "error writing response", "error", err)
+	}
+}
+
+func ImportPresentation(w http.ResponseWriter, r *http.Request) {
+	bodyBytes, err := utilities.ReadRequestBody(r)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = importFormat(r.Header.Get("Content-Type"))
+	}
+
+	presentation, err := importer.Parse(format, bodyBytes)
+	var importErrors importer.Errors
+	if errors.As(err, &importErrors) {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentation.WithErrors(importErrors), err)
+		return
+	}
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidParameter.WithDetail("Format must be one of yaml, json or markdown"), err)
+		return
+	}
+
+	bodyBytes, err = json.Marshal(presentation)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("failed to encode presentation: %w", err))
+		return
+	}
+
+	createPresentation(w, r, bodyBytes)
+}
+
+func importFormat(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return importer.FormatYAML
+	case "text/markdown", "text/x-markdown":
+		return importer.FormatMarkdown
+	default:
+		return importer.FormatJSON
 	}
 }
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+
+	"interactive-presentation/src/qr"
+	"interactive-presentation/src/utilities"
+)
+
+func GetQRCodePNG(w http.ResponseWriter, r *http.Request) {
+	writeQRCode(w, r, "image/png", qr.PNG)
+}
+
+func GetQRCodeSVG(w http.ResponseWriter, r *http.Request) {
+	writeQRCode(w, r, "image/svg+xml", qr.SVG)
+}
+
+func writeQRCode(w http.ResponseWriter, r *http.Request, contentType string, render func(string, int, qrcode.RecoveryLevel) ([]byte, error)) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
+
+	size := qr.DefaultSize
+	if sizeParam := r.URL.Query().Get("size"); sizeParam != "" {
+		size, err = strconv.Atoi(sizeParam)
+		if err != nil || size < qr.MinSize || size > qr.MaxSize {
+			utilities.WriteProblem(w, r, utilities.ProblemInvalidParameter.WithDetail("Size must be between %d and %d", qr.MinSize, qr.MaxSize), nil)
+			return
+		}
+	}
+
+	level, err := qr.ParseLevel(r.URL.Query().Get("level"))
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidParameter.WithDetail("Level must be one of L, M, Q or H"), err)
+		return
+	}
+
+	joinCode, ok := currentJoinCode(w, r, presentationUUID)
+	if !ok {
+		return
+	}
+
+	url, ok := joinURL(r, joinCode.Code)
+	if !ok {
+		utilities.WriteProblem(w, r, utilities.ProblemHostNotAllowed.WithDetail("Set public_url or list the host in allowed_hosts"), fmt.Errorf("host %q is not allowed", r.Host))
+		return
+	}
+
+	image, err := render(url, size, level)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("failed to render QR code: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	_, err = w.Write(image)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "error writing QR code", "error", err)
+	}
+}
+
+// joinURL builds the audience join URL for a code, using the public URL when
+// it is configured and the scheme and host of the request otherwise. Without
+// a public URL the host has to be one of the allowed hosts, and the scheme of
+// X-Forwarded-Proto is only used behind a trusted proxy, as clients could
+// otherwise choose the link encoded in QR codes. It reports false for hosts
+// that are not allowed.
+func joinURL(r *http.Request, code string) (string, bool) {
+	if publicURL != "" {
+		return publicURL + "/join/" + code, true
+	}
+	if !isAllowedHost(r.Host) {
+		return "", false
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if forwardedProto := r.Header.Get("X-Forwarded-Proto"); trustProxy && (forwardedProto == "http" || forwardedProto == "https") {
+		scheme = forwardedProto
+	}
+	return scheme + "://" + r.Host + "/join/" + code, true
+}
+
+// isAllowedHost reports whether a Host header names one of the allowed hosts,
+// with or without its port.
+func isAllowedHost(host string) bool {
+	host = strings.ToLower(host)
+	hostname := host
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		hostname = name
+	}
+	for _, allowed := range allowedHosts {
+		if allowed == host || allowed == hostname {
+			return true
+		}
+	}
+	return false
+}
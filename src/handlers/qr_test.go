@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinURL(t *testing.T) {
+	configure := func(t *testing.T, url string, trusted bool) {
+		previousURL, previousTrust, previousHosts := publicURL, trustProxy, allowedHosts
+		publicURL, trustProxy, allowedHosts = url, trusted, []string{"vote.example.com"}
+		t.Cleanup(func() {
+			publicURL, trustProxy, allowedHosts = previousURL, previousTrust, previousHosts
+		})
+	}
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		configure(t, "https://vote.example.com", false)
+		r := newRequest(http.MethodGet, "http://internal:8080/qr.png", "")
+		r.Header.Set("X-Forwarded-Proto", "http")
+
+		// Act
+		url, ok := joinURL(r, "ABC123")
+
+		// Assert
+		assert.True(t, ok)
+		assert.Equal(t, "https://vote.example.com/join/ABC123", url)
+	})
+
+	t.Run("Untrusted Forwarded Proto", func(t *testing.T) {
+		// Arrange
+		configure(t, "", false)
+		r := newRequest(http.MethodGet, "http://vote.example.com/qr.png", "")
+		r.Header.Set("X-Forwarded-Proto", "javascript")
+
+		// Act
+		url, ok := joinURL(r, "ABC123")
+
+		// Assert
+		assert.True(t, ok)
+		assert.Equal(t, "http://vote.example.com/join/ABC123", url)
+	})
+
+	t.Run("Trusted Proxy", func(t *testing.T) {
+		// Arrange
+		configure(t, "", true)
+		r := newRequest(http.MethodGet, "http://vote.example.com:8443/qr.png", "")
+		r.Header.Set("X-Forwarded-Proto", "https")
+
+		// Act
+		url, ok := joinURL(r, "ABC123")
+
+		// Assert
+		assert.True(t, ok)
+		assert.Equal(t, "https://vote.example.com:8443/join/ABC123", url)
+	})
+
+	t.Run("Host Not Allowed", func(t *testing.T) {
+		// Arrange
+		configure(t, "", true)
+		r := newRequest(http.MethodGet, "http://evil.example.net/qr.png", "")
+
+		// Act
+		_, ok := joinURL(r, "ABC123")
+
+		// Assert
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"interactive-presentation/src/metrics"
+	"interactive-presentation/src/models"
+	"interactive-presentation/src/reactions"
+	"interactive-presentation/src/utilities"
+)
+
+func PostReaction(w http.ResponseWriter, r *http.Request) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
+
+	bodyBytes, err := utilities.ReadRequestBody(r)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
+		return
+	}
+
+	var reaction models.Reaction
+	if err = json.Unmarshal(bodyBytes, &reaction); err != nil || reaction.ClientID == "" {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidRequestBody, err)
+		return
+	}
+
+	err = reactionHub.Record(presentationUUID, reaction.ClientID, reaction.Emoji)
+	if errors.Is(err, reactions.ErrUnknownEmoji) {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidRequestBody.WithDetail("Unknown emoji"), err)
+		return
+	}
+	if errors.Is(err, reactions.ErrRateLimited) {
+		w.Header().Set("Retry-After", "1")
+		utilities.WriteProblem(w, r, utilities.ProblemRateLimited.WithDetail("Too many reactions"), err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+var (
+	// streamsClosing is closed when the server shuts down to end every live
+	// stream.
+	streamsClosing   = make(chan struct{})
+	closeStreamsOnce sync.Once
+)
+
+// CloseStreams ends every live stream with a final shutdown event, telling
+// clients to reconnect, e.g. to another instance. It is meant to be
+// registered with http.Server.RegisterOnShutdown, which otherwise waits for
+// streams to end by themselves.
+func CloseStreams() {
+	closeStreamsOnce.Do(func() {
+		close(streamsClosing)
+	})
+}
+
+// StreamReactions sends the reaction counters of the previous second to the
+// presenter view once per second as server-sent events.
+func StreamReactions(w http.ResponseWriter, r *http.Request) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, errors.New("streaming unsupported"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// The stream is meant to outlive the server's write timeout.
+	if err = http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		slog.WarnContext(r.Context(), "error clearing write deadline of reactions stream", "error", err)
+	}
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	liveConnections := metrics.LiveConnections.WithLabelValues("reactions")
+	liveConnections.Inc()
+	defer liveConnections.Dec()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-streamsClosing:
+			if _, err = fmt.Fprint(w, "event: shutdown\ndata: {}\n\n"); err != nil {
+				slog.ErrorContext(r.Context(), "error writing shutdown event", "error", err)
+			}
+			flusher.Flush()
+			return
+		case <-ticker.C:
+			second := reactionHub.Now() - 1
+			data, err := json.Marshal(models.ReactionCounts{Second: second, Counts: reactionHub.Counts(presentationUUID, second)})
+			if err != nil {
+				slog.ErrorContext(r.Context(), "error encoding reactions", "error", err)
+				return
+			}
+			if _, err = fmt.Fprintf(w, "event: reactions\ndata: %s\n\n", data); err != nil {
+				slog.ErrorContext(r.Context(), "error writing reactions event", "error", err)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
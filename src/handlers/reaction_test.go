@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamReactions(t *testing.T) {
+	t.Run("Shutdown", func(t *testing.T) {
+		// Arrange
+		t.Cleanup(func() {
+			streamsClosing = make(chan struct{})
+			closeStreamsOnce = sync.Once{}
+		})
+		router := chi.NewRouter()
+		router.Get("/presentations/{presentation_id}/reactions/stream", StreamReactions)
+		server := httptest.NewUnstartedServer(router)
+		closed := make(chan struct{})
+		server.Config.RegisterOnShutdown(func() {
+			CloseStreams()
+			close(closed)
+		})
+		server.Start()
+		defer server.Close()
+
+		response, err := http.Get(fmt.Sprintf("%s/presentations/%s/reactions/stream", server.URL, uuid.New()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+
+		// Act
+		body := make(chan string)
+		go func() {
+			data, _ := io.ReadAll(response.Body)
+			body <- string(data)
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err = server.Config.Shutdown(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+		select {
+		case data := <-body:
+			assert.Regexp(t, "event: shutdown\ndata: \\{\\}\n\n$", data)
+		case <-time.After(5 * time.Second):
+			t.Fatal("stream did not end after CloseStreams")
+		}
+		<-closed
+	})
+}
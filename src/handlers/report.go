@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"interactive-presentation/src/models"
+	"interactive-presentation/src/report"
+	"interactive-presentation/src/storage"
+	"interactive-presentation/src/utilities"
+)
+
+func GetReport(w http.ResponseWriter, r *http.Request) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "html"
+	}
+	if format != "html" && format != "md" {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidParameter.WithDetail("Format must be one of html or md"), nil)
+		return
+	}
+
+	var presentations []models.PresentationDB
+	err = storage.SelectFromTable(r.Context(), "presentation", presentationUUID, &presentations)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from presentation table: %w", err))
+		return
+	}
+	if len(presentations) == 0 {
+		utilities.WriteProblem(w, r, utilities.ProblemPresentationNotFound, nil)
+		return
+	}
+
+	results, err := loadPresentationResults(r.Context(), presentationUUID)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error loading presentation results: %w", err))
+		return
+	}
+
+	var pollsShown []models.PollShownDB
+	err = storage.SelectFromTable(r.Context(), "poll_shown", presentationUUID, &pollsShown)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from poll_shown table: %w", err))
+		return
+	}
+
+	sessionReport := buildReport(presentations[0], results, pollsShown)
+
+	if format == "md" {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		err = report.Markdown(w, sessionReport)
+	} else {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		err = report.HTML(w, sessionReport)
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "error rendering report", "error", err)
+	}
+}
+
+func buildReport(presentation models.PresentationDB, results []pollResults, pollsShown []models.PollShownDB) report.Report {
+	participants := make(map[string]bool)
+	for _, result := range results {
+		for _, vote := range result.Votes {
+			participants[vote.ClientID] = true
+		}
+	}
+
+	sessionReport := report.Report{
+		PresentationID: presentation.PresentationID,
+		GeneratedAt:    time.Now(),
+		Participants:   len(participants),
+	}
+	for _, result := range results {
+		voters := make(map[string]bool)
+		for _, vote := range result.Votes {
+			voters[vote.ClientID] = true
+		}
+
+		poll := report.Poll{
+			Index:    result.Poll.Index,
+			Question: result.Poll.Question,
+			Votes:    len(result.Votes),
+		}
+		if len(participants) > 0 {
+			poll.Participation = float64(len(voters)) * 100 / float64(len(participants))
+		}
+		for _, shown := range pollsShown {
+			if shown.PollIndex == result.Poll.Index {
+				poll.ShownAt = append(poll.ShownAt, shown.ShownAt)
+			}
+		}
+		for _, option := range result.Options {
+			reportOption := report.Option{Key: option.Key, Value: option.Value, Count: result.Count(option.Key)}
+			if len(result.Votes) > 0 {
+				reportOption.Percentage = float64(reportOption.Count) * 100 / float64(len(result.Votes))
+			}
+			poll.Options = append(poll.Options, reportOption)
+		}
+		sessionReport.Polls = append(sessionReport.Polls, poll)
+	}
+	return sessionReport
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"interactive-presentation/src/models"
+	"interactive-presentation/src/storage"
+	"interactive-presentation/src/utilities"
+)
+
+// pollResults holds a stored poll together with its options in display order
+// and the votes recorded for it.
+type pollResults struct {
+	Poll    models.PollDB
+	Options []models.OptionDB
+	Votes   []models.Vote
+}
+
+// Count returns the number of votes recorded for an option key.
+func (p pollResults) Count(key string) int {
+	count := 0
+	for _, vote := range p.Votes {
+		if vote.Key == key {
+			count++
+		}
+	}
+	return count
+}
+
+// loadPresentationResults reads every poll of a presentation ordered by index
+// with its options and votes.
+func loadPresentationResults(ctx context.Context, presentationUUID uuid.UUID) ([]pollResults, error) {
+	var polls []models.PollDB
+	err := storage.SelectFromTable(ctx, "poll", presentationUUID, &polls)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting from poll table: %v", err)
+	}
+	sort.Slice(polls, func(i, j int) bool {
+		return polls[i].Index < polls[j].Index
+	})
+
+	var results []pollResults
+	for _, poll := range polls {
+		result, err := loadPollResults(ctx, poll)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func loadPollResults(ctx context.Context, poll models.PollDB) (pollResults, error) {
+	var options []models.OptionDB
+	err := storage.SelectFromTable(ctx, "option", poll.PollID, &options)
+	if err != nil {
+		return pollResults{}, fmt.Errorf("error selecting from option table: %v", err)
+	}
+	sort.Slice(options, func(i, j int) bool {
+		return options[i].Index < options[j].Index
+	})
+
+	var votes []models.Vote
+	err = storage.SelectFromTable(ctx, "vote", poll.PollID, &votes)
+	if err != nil {
+		return pollResults{}, fmt.Errorf("error selecting from vote table: %v", err)
+	}
+
+	return pollResults{Poll: poll, Options: options, Votes: votes}, nil
+}
+
+// Results returns the vote count of every option without revealing who voted.
+func (p pollResults) Results() models.PollResults {
+	results := models.PollResults{PollID: p.Poll.PollID, Question: p.Poll.Question, Votes: len(p.Votes), Options: []models.OptionResult{}}
+	for _, option := range p.Options {
+		results.Options = append(results.Options, models.OptionResult{Key: option.Key, Value: option.Value, Votes: p.Count(option.Key)})
+	}
+	return results
+}
+
+// ResultsV2 returns the results of the v2 API, adding the state of the poll,
+// the number of clients that voted and the share of every option.
+func (p pollResults) ResultsV2() models.PollResultsV2 {
+	participants := make(map[string]bool)
+	for _, vote := range p.Votes {
+		participants[vote.ClientID] = true
+	}
+	return models.PollResultsV2{
+		PollID:       p.Poll.PollID,
+		Question:     p.Poll.Question,
+		Index:        p.Poll.Index,
+		Status:       pollStatus(p.Poll),
+		Votes:        len(p.Votes),
+		Participants: len(participants),
+		Options:      p.optionResultsV2(),
+	}
+}
+
+// PollV2 returns the poll as the current poll of the v2 API in a
+// presentation of the given number of polls.
+func (p pollResults) PollV2(polls int) models.PollV2 {
+	return models.PollV2{
+		PollID:   p.Poll.PollID,
+		Question: p.Poll.Question,
+		Index:    p.Poll.Index,
+		Polls:    polls,
+		Status:   pollStatus(p.Poll),
+		Votes:    len(p.Votes),
+		Options:  p.optionResultsV2(),
+	}
+}
+
+func (p pollResults) optionResultsV2() []models.OptionResultV2 {
+	options := []models.OptionResultV2{}
+	for _, option := range p.Options {
+		result := models.OptionResultV2{Key: option.Key, Value: option.Value, Votes: p.Count(option.Key)}
+		if len(p.Votes) > 0 {
+			result.Percentage = float64(result.Votes) * 100 / float64(len(p.Votes))
+		}
+		options = append(options, result)
+	}
+	return options
+}
+
+func GetPollResults(w http.ResponseWriter, r *http.Request) {
+	result, ok := requestedPollResults(w, r)
+	if !ok {
+		return
+	}
+
+	_ = utilities.WriteJSONResponse(w, result.Results())
+}
+
+// requestedPresentation loads the presentation named in the request. It
+// answers the request itself when that fails, reporting whether the handler
+// may go on.
+func requestedPresentation(w http.ResponseWriter, r *http.Request) (models.PresentationDB, bool) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return models.PresentationDB{}, false
+	}
+
+	var presentations []models.PresentationDB
+	err = storage.SelectFromTable(r.Context(), "presentation", presentationUUID, &presentations)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from presentation table: %w", err))
+		return models.PresentationDB{}, false
+	}
+	if len(presentations) == 0 {
+		utilities.WriteProblem(w, r, utilities.ProblemPresentationNotFound, nil)
+		return models.PresentationDB{}, false
+	}
+
+	return presentations[0], true
+}
+
+// requestedPollResults loads the results of the poll named in the request. It
+// answers the request itself when that fails or the client already has the
+// current results, reporting whether the handler may go on.
+func requestedPollResults(w http.ResponseWriter, r *http.Request) (pollResults, bool) {
+	presentation, ok := requestedPresentation(w, r)
+	if !ok {
+		return pollResults{}, false
+	}
+
+	pollUUID, err := utilities.ParseUUIDFromRequest(r, "poll_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPollID, err)
+		return pollResults{}, false
+	}
+
+	poll, err := presentationPoll(r.Context(), presentation.PresentationID, pollUUID)
+	if errors.Is(err, errPollNotFound) {
+		utilities.WriteProblem(w, r, utilities.ProblemPollNotFound, nil)
+		return pollResults{}, false
+	}
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
+		return pollResults{}, false
+	}
+
+	if pollNotModified(w, r, presentation, poll.Index) {
+		return pollResults{}, false
+	}
+
+	result, err := loadPollResults(r.Context(), poll)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error loading poll results: %w", err))
+		return pollResults{}, false
+	}
+	return result, true
+}
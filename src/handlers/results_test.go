@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"interactive-presentation/src/models"
+)
+
+func TestGetPollResults(t *testing.T) {
+	pattern := "/presentations/{presentation_id}/polls/{poll_id}/results"
+	presentation := models.PresentationDB{PresentationID: uuid.New(), Version: 3}
+	poll := models.PollDB{PollID: uuid.New(), PresentationID: presentation.PresentationID}
+
+	t.Run("Not Modified", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM presentation").WillReturnRows(presentationRows(presentation))
+		mock.ExpectQuery("SELECT \\* FROM poll").WillReturnRows(pollRows(poll))
+		mock.ExpectQuery("SELECT COALESCE\\(MAX\\(vote_id\\), 0\\) FROM vote").WithArgs(presentation.PresentationID, poll.Index).
+			WillReturnRows(sqlmock.NewRows([]string{"vote_id"}).AddRow(41))
+		r := newRequest(http.MethodGet, fmt.Sprintf("/presentations/%s/polls/%s/results", presentation.PresentationID, poll.PollID), "")
+		r.Header.Set("If-None-Match", pollETag(presentation, 41))
+
+		// Act
+		w := serve(GetPollResults, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("New Vote", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM presentation").WillReturnRows(presentationRows(presentation))
+		mock.ExpectQuery("SELECT \\* FROM poll").WillReturnRows(pollRows(poll))
+		mock.ExpectQuery("SELECT COALESCE\\(MAX\\(vote_id\\), 0\\) FROM vote").WithArgs(presentation.PresentationID, poll.Index).
+			WillReturnRows(sqlmock.NewRows([]string{"vote_id"}).AddRow(42))
+		mock.ExpectQuery("SELECT \\* FROM option").WillReturnRows(sqlmock.NewRows([]string{"key", "value", "poll_id", "index"}).AddRow("a", "Cats", poll.PollID, 0))
+		mock.ExpectQuery("SELECT key, client_id, poll_id FROM vote").WillReturnRows(sqlmock.NewRows([]string{"key", "client_id", "poll_id"}).AddRow("a", "client-1", poll.PollID))
+		r := newRequest(http.MethodGet, fmt.Sprintf("/presentations/%s/polls/%s/results", presentation.PresentationID, poll.PollID), "")
+		r.Header.Set("If-None-Match", pollETag(presentation, 41))
+
+		// Act
+		w := serve(GetPollResults, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, pollETag(presentation, 42), w.Header().Get("ETag"))
+		assert.Contains(t, w.Body.String(), `"votes":1`)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Poll", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM presentation").WillReturnRows(presentationRows(presentation))
+		mock.ExpectQuery("SELECT \\* FROM poll").WillReturnRows(pollRows(poll))
+		r := newRequest(http.MethodGet, fmt.Sprintf("/presentations/%s/polls/%s/results", presentation.PresentationID, uuid.New()), "")
+		r.Header.Set("If-None-Match", pollETag(presentation, 0))
+
+		// Act
+		w := serve(GetPollResults, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "poll_not_found", problemCode(t, w))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"interactive-presentation/src/models"
+	"interactive-presentation/src/moderation"
+	"interactive-presentation/src/storage"
+	"interactive-presentation/src/utilities"
+)
+
+const (
+	maxSubmissionLength = 500
+)
+
+func PostSubmission(w http.ResponseWriter, r *http.Request) {
+	presentation, ok := requestedPresentation(w, r)
+	if !ok {
+		return
+	}
+
+	bodyBytes, err := utilities.ReadRequestBody(r)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
+		return
+	}
+
+	var submission models.Submission
+	if err = json.Unmarshal(bodyBytes, &submission); err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidRequestBody, err)
+		return
+	}
+	submission.Text = strings.TrimSpace(submission.Text)
+	if submission.Text == "" || utf8.RuneCountInString(submission.Text) > maxSubmissionLength {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidRequestBody.WithDetail("Text must be between 1 and %d characters", maxSubmissionLength), nil)
+		return
+	}
+
+	// Submissions always go to the current poll, a poll_id in the body only
+	// guards against submitting to a poll that is no longer shown.
+	poll, err := currentPoll(r.Context(), presentation)
+	if errors.Is(err, errPollNotFound) {
+		utilities.WriteProblem(w, r, utilities.ProblemPollNotFound, nil)
+		return
+	}
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
+		return
+	}
+	if submission.PollID != uuid.Nil && submission.PollID != poll.PollID {
+		utilities.WriteProblem(w, r, utilities.ProblemPollNotCurrent, nil)
+		return
+	}
+	if poll.Closed {
+		utilities.WriteProblem(w, r, utilities.ProblemPollClosed, nil)
+		return
+	}
+	submission.PollID = poll.PollID
+
+	var blocklist []models.BlocklistDB
+	err = storage.SelectFromTable(r.Context(), "blocklist", presentation.PresentationID, &blocklist)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from blocklist table: %w", err))
+		return
+	}
+	var words []string
+	for _, entry := range blocklist {
+		words = append(words, entry.Word)
+	}
+
+	submission.SubmissionID = uuid.New()
+	submission.Status = models.SubmissionPending
+	if moderation.IsBlocked(submission.Text, words) {
+		submission.Status = models.SubmissionRejected
+	}
+	submission.CreatedAt = time.Now().UTC()
+
+	if err = storage.InsertIntoDatabase(r.Context(), "submission", submission); err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error inserting into submission database: %w", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = utilities.WriteJSONResponse(w, submission)
+}
+
+func GetApprovedSubmissions(w http.ResponseWriter, r *http.Request) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
+
+	pollUUID, err := utilities.ParseUUIDFromRequest(r, "poll_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPollID, err)
+		return
+	}
+
+	_, err = presentationPoll(r.Context(), presentationUUID, pollUUID)
+	if errors.Is(err, errPollNotFound) {
+		utilities.WriteProblem(w, r, utilities.ProblemPollNotFound, nil)
+		return
+	}
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
+		return
+	}
+
+	var submissions []models.Submission
+	err = storage.SelectFromTable(r.Context(), "submission", pollUUID, &submissions)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting submissions: %w", err))
+		return
+	}
+
+	approved := []models.Submission{}
+	for _, submission := range submissions {
+		if submission.Status == models.SubmissionApproved {
+			approved = append(approved, submission)
+		}
+	}
+
+	_ = utilities.WriteJSONResponse(w, &approved)
+}
+
+func GetSubmissions(w http.ResponseWriter, r *http.Request) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = models.SubmissionPending
+	}
+	if !isSubmissionStatus(status) {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidParameter.WithDetail("Status must be one of pending, approved or rejected"), nil)
+		return
+	}
+
+	var polls []models.PollDB
+	err = storage.SelectFromTable(r.Context(), "poll", presentationUUID, &polls)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from poll table: %w", err))
+		return
+	}
+
+	queue := []models.Submission{}
+	for _, poll := range polls {
+		var submissions []models.Submission
+		err = storage.SelectFromTable(r.Context(), "submission", poll.PollID, &submissions)
+		if err != nil {
+			utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting submissions: %w", err))
+			return
+		}
+		for _, submission := range submissions {
+			if submission.Status == status {
+				queue = append(queue, submission)
+			}
+		}
+	}
+	sort.Slice(queue, func(i, j int) bool {
+		return queue[i].CreatedAt.Before(queue[j].CreatedAt)
+	})
+
+	_ = utilities.WriteJSONResponse(w, &queue)
+}
+
+func PutSubmissionStatus(w http.ResponseWriter, r *http.Request) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
+
+	submissionUUID, err := utilities.ParseUUIDFromRequest(r, "submission_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidSubmissionID, err)
+		return
+	}
+
+	bodyBytes, err := utilities.ReadRequestBody(r)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
+		return
+	}
+
+	var submissionStatus models.SubmissionStatus
+	if err = json.Unmarshal(bodyBytes, &submissionStatus); err != nil || !isSubmissionStatus(submissionStatus.Status) {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidRequestBody, err)
+		return
+	}
+
+	updated, err := storage.UpdateSubmissionStatus(r.Context(), presentationUUID, submissionUUID, submissionStatus.Status)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error updating submission table: %w", err))
+		return
+	}
+	if updated == 0 {
+		utilities.WriteProblem(w, r, utilities.ProblemSubmissionNotFound, nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func isSubmissionStatus(status string) bool {
+	switch status {
+	case models.SubmissionPending, models.SubmissionApproved, models.SubmissionRejected:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"interactive-presentation/src/models"
+)
+
+func presentationRows(presentation models.PresentationDB) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"presentation_id", "current_poll_index", "version"}).
+		AddRow(presentation.PresentationID, presentation.CurrentPollIndex, presentation.Version)
+}
+
+func TestPostSubmission(t *testing.T) {
+	pattern := "/presentations/{presentation_id}/polls/current/submissions"
+	presentation := models.PresentationDB{PresentationID: uuid.New(), CurrentPollIndex: 1, Version: 1}
+	previous := models.PollDB{PollID: uuid.New(), PresentationID: presentation.PresentationID, Index: 0}
+	current := models.PollDB{PollID: uuid.New(), PresentationID: presentation.PresentationID, Index: 1}
+	target := fmt.Sprintf("/presentations/%s/polls/current/submissions", presentation.PresentationID)
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM presentation").WillReturnRows(presentationRows(presentation))
+		mock.ExpectQuery("SELECT \\* FROM poll").WillReturnRows(pollRows(previous, current))
+		mock.ExpectQuery("SELECT \\* FROM blocklist").WithArgs(presentation.PresentationID).
+			WillReturnRows(sqlmock.NewRows([]string{"presentation_id", "word"}).AddRow(presentation.PresentationID, "spam"))
+		mock.ExpectExec("INSERT INTO submission").
+			WithArgs(sqlmock.AnyArg(), current.PollID, "client-1", "No spam please", models.SubmissionRejected, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		r := newRequest(http.MethodPost, target, `{"client_id":"client-1","text":" No spam please "}`)
+
+		// Act
+		w := serve(PostSubmission, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), current.PollID.String())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Poll Not Current", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM presentation").WillReturnRows(presentationRows(presentation))
+		mock.ExpectQuery("SELECT \\* FROM poll").WillReturnRows(pollRows(previous, current))
+		r := newRequest(http.MethodPost, target, fmt.Sprintf(`{"poll_id":"%s","client_id":"client-1","text":"Hello"}`, uuid.New()))
+
+		// Act
+		w := serve(PostSubmission, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "poll_not_current", problemCode(t, w))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Poll Closed", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		closed := current
+		closed.Closed = true
+		mock.ExpectQuery("SELECT \\* FROM presentation").WillReturnRows(presentationRows(presentation))
+		mock.ExpectQuery("SELECT \\* FROM poll").WillReturnRows(pollRows(previous, closed))
+		r := newRequest(http.MethodPost, target, fmt.Sprintf(`{"poll_id":"%s","client_id":"client-1","text":"Hello"}`, current.PollID))
+
+		// Act
+		w := serve(PostSubmission, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "poll_closed", problemCode(t, w))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPutSubmissionStatus(t *testing.T) {
+	pattern := "/presentations/{presentation_id}/submissions/{submission_id}"
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		presentationID, submissionID := uuid.New(), uuid.New()
+		mock.ExpectExec("UPDATE submission SET status").WithArgs("approved", submissionID, presentationID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		r := newRequest(http.MethodPut, fmt.Sprintf("/presentations/%s/submissions/%s", presentationID, submissionID), `{"status":"approved"}`)
+
+		// Act
+		w := serve(PutSubmissionStatus, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Submission Of Another Presentation", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		presentationID, foreignSubmissionID := uuid.New(), uuid.New()
+		mock.ExpectExec("UPDATE submission SET status").WithArgs("approved", foreignSubmissionID, presentationID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		r := newRequest(http.MethodPut, fmt.Sprintf("/presentations/%s/submissions/%s", presentationID, foreignSubmissionID), `{"status":"approved"}`)
+
+		// Act
+		w := serve(PutSubmissionStatus, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "submission_not_found", problemCode(t, w))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetApprovedSubmissions(t *testing.T) {
+	pattern := "/presentations/{presentation_id}/polls/{poll_id}/submissions"
+	presentationID := uuid.New()
+	poll := models.PollDB{PollID: uuid.New(), PresentationID: presentationID}
+	columns := []string{"submission_id", "poll_id", "client_id", "text", "status", "created_at"}
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM poll").WithArgs(presentationID).WillReturnRows(pollRows(poll))
+		mock.ExpectQuery("SELECT \\* FROM submission").WithArgs(poll.PollID).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(uuid.New(), poll.PollID, "client-1", "Approved", models.SubmissionApproved, time.Now()).
+				AddRow(uuid.New(), poll.PollID, "client-2", "Pending", models.SubmissionPending, time.Now()))
+		r := newRequest(http.MethodGet, fmt.Sprintf("/presentations/%s/polls/%s/submissions", presentationID, poll.PollID), "")
+
+		// Act
+		w := serve(GetApprovedSubmissions, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Approved")
+		assert.NotContains(t, w.Body.String(), "Pending")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Poll Of Another Presentation", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM poll").WithArgs(presentationID).WillReturnRows(pollRows(poll))
+		r := newRequest(http.MethodGet, fmt.Sprintf("/presentations/%s/polls/%s/submissions", presentationID, uuid.New()), "")
+
+		// Act
+		w := serve(GetApprovedSubmissions, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "poll_not_found", problemCode(t, w))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"interactive-presentation/src/utilities"
+	"interactive-presentation/src/validation"
+)
+
+// validateRequestBody checks body against the rules of its model and answers
+// with the field errors when it is invalid, reporting whether the handler may
+// go on.
+func validateRequestBody(w http.ResponseWriter, r *http.Request, body interface{}) bool {
+	err := validation.Validate(body)
+	if err == nil {
+		return true
+	}
+
+	var fieldErrors validation.Errors
+	if errors.As(err, &fieldErrors) {
+		utilities.WriteProblem(w, r, utilities.ProblemValidationFailed.WithErrors(fieldErrors), err)
+		return false
+	}
+	utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
+	return false
+}
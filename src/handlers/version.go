@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"interactive-presentation/src/models"
+	"interactive-presentation/src/storage"
+	"interactive-presentation/src/utilities"
+)
+
+// DeprecateV1 marks responses of the v1 API, served under /v1 and without a
+// prefix, as deprecated and links the same route of the v2 API.
+func DeprecateV1(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		if strings.HasPrefix(path, "/v1/") {
+			path = strings.TrimPrefix(path, "/v1")
+		}
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", fmt.Sprintf(`</v2%s>; rel="successor-version"`, path))
+		next.ServeHTTP(w, r)
+	})
+}
+
+func GetCurrentPollV2(w http.ResponseWriter, r *http.Request) {
+	presentation, ok := requestedPresentation(w, r)
+	if !ok || pollNotModified(w, r, presentation, presentation.CurrentPollIndex) {
+		return
+	}
+
+	writeCurrentPollV2(w, r, presentation)
+}
+
+func PutCurrentPollV2(w http.ResponseWriter, r *http.Request) {
+	if _, _, ok := moveCurrentPoll(w, r); !ok {
+		return
+	}
+
+	presentation, ok := requestedPresentation(w, r)
+	if !ok {
+		return
+	}
+
+	writeCurrentPollV2(w, r, presentation)
+}
+
+func GetPollResultsV2(w http.ResponseWriter, r *http.Request) {
+	result, ok := requestedPollResults(w, r)
+	if !ok {
+		return
+	}
+
+	_ = utilities.WriteJSONResponse(w, result.ResultsV2())
+}
+
+func writeCurrentPollV2(w http.ResponseWriter, r *http.Request, presentation models.PresentationDB) {
+	poll, err := loadCurrentPollV2(r.Context(), presentation)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error loading current poll: %w", err))
+		return
+	}
+
+	_ = utilities.WriteJSONResponse(w, poll)
+}
+
+// loadCurrentPollV2 reads the current poll of a presentation with its votes.
+// Past the last poll it returns a closed poll without ID, question or options.
+func loadCurrentPollV2(ctx context.Context, presentation models.PresentationDB) (models.PollV2, error) {
+	var polls []models.PollDB
+	err := storage.SelectFromTable(ctx, "poll", presentation.PresentationID, &polls)
+	if err != nil {
+		return models.PollV2{}, fmt.Errorf("error selecting from poll table: %v", err)
+	}
+
+	index := presentation.CurrentPollIndex
+	for _, poll := range polls {
+		if poll.Index != index {
+			continue
+		}
+		result, err := loadPollResults(ctx, poll)
+		if err != nil {
+			return models.PollV2{}, err
+		}
+		return result.PollV2(len(polls)), nil
+	}
+
+	return models.PollV2{Index: index, Polls: len(polls), Status: models.PollClosed, Options: []models.OptionResultV2{}}, nil
+}
@@ -2,52 +2,114 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"net/http"
 
+	"interactive-presentation/src/metrics"
 	"interactive-presentation/src/models"
 	"interactive-presentation/src/storage"
 	"interactive-presentation/src/utilities"
+	"interactive-presentation/src/validation"
 )
 
 func PostPollVote(w http.ResponseWriter, r *http.Request) {
+	presentation, ok := requestedPresentation(w, r)
+	if !ok {
+		return
+	}
+
 	bodyBytes, err := utilities.ReadRequestBody(r)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
 		return
 	}
 
 	var vote models.Vote
 	if err = json.Unmarshal(bodyBytes, &vote); err != nil {
-		log.Println(err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidRequestBody, err)
+		return
+	}
+	if !validateRequestBody(w, r, vote) {
+		return
+	}
+
+	poll, err := presentationPoll(r.Context(), presentation.PresentationID, vote.PollID)
+	if errors.Is(err, errPollNotFound) {
+		utilities.WriteProblem(w, r, utilities.ProblemPollNotFound, nil)
+		return
+	}
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
+		return
+	}
+	if poll.Index != presentation.CurrentPollIndex {
+		utilities.WriteProblem(w, r, utilities.ProblemPollNotCurrent, nil)
+		return
+	}
+	if poll.Closed {
+		utilities.WriteProblem(w, r, utilities.ProblemPollClosed, nil)
+		return
+	}
+
+	var options []models.OptionDB
+	err = storage.SelectFromTable(r.Context(), "option", poll.PollID, &options)
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting from option table: %w", err))
+		return
+	}
+	if !hasOption(options, vote.Key) {
+		utilities.WriteProblem(w, r, utilities.ProblemValidationFailed.WithErrors(validation.Errors{{Field: "key", Message: "must be the key of an option of the poll"}}), nil)
 		return
 	}
 
-	if err = storage.InsertIntoDatabase("vote", vote); err != nil {
-		log.Println(err)
-		http.Error(w, "Error inserting into vote database", http.StatusInternalServerError)
+	if err = storage.InsertIntoDatabase(r.Context(), "vote", vote); err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error inserting into vote database: %w", err))
 		return
 	}
+	metrics.VotesRecorded.Inc()
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func hasOption(options []models.OptionDB, key string) bool {
+	for _, option := range options {
+		if option.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
 func GetPollVotes(w http.ResponseWriter, r *http.Request) {
+	presentationUUID, err := utilities.ParseUUIDFromRequest(r, "presentation_id")
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPresentationID, err)
+		return
+	}
+
 	pollUUID, err := utilities.ParseUUIDFromRequest(r, "poll_id")
 	if err != nil {
-		log.Println(err)
-		http.Error(w, "Invalid poll ID", http.StatusBadRequest)
+		utilities.WriteProblem(w, r, utilities.ProblemInvalidPollID, err)
+		return
+	}
+
+	// The presenter token only covers the presentation in the URL, so the poll
+	// has to belong to it.
+	_, err = presentationPoll(r.Context(), presentationUUID, pollUUID)
+	if errors.Is(err, errPollNotFound) {
+		utilities.WriteProblem(w, r, utilities.ProblemPollNotFound, nil)
+		return
+	}
+	if err != nil {
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, err)
 		return
 	}
 
 	var votes []models.Vote
-	err = storage.SelectFromTable("vote", pollUUID, &votes)
+	err = storage.SelectFromTable(r.Context(), "vote", pollUUID, &votes)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, fmt.Sprintf("Error selecting votes: %v", err), http.StatusInternalServerError)
+		utilities.WriteProblem(w, r, utilities.ProblemInternal, fmt.Errorf("error selecting votes: %w", err))
 		return
 	}
 
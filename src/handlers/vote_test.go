@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"interactive-presentation/src/models"
+)
+
+func TestPostPollVote(t *testing.T) {
+	pattern := "/presentations/{presentation_id}/polls/current/votes"
+	presentation := models.PresentationDB{PresentationID: uuid.New(), CurrentPollIndex: 1, Version: 1}
+	poll := models.PollDB{PollID: uuid.New(), PresentationID: presentation.PresentationID, Index: 1}
+	previousPoll := models.PollDB{PollID: uuid.New(), PresentationID: presentation.PresentationID, Index: 0}
+	target := fmt.Sprintf("/presentations/%s/polls/current/votes", presentation.PresentationID)
+	body := func(key string, pollID uuid.UUID) string {
+		return fmt.Sprintf(`{"key":"%s","client_id":"client-1","poll_id":"%s"}`, key, pollID)
+	}
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM presentation").WillReturnRows(presentationRows(presentation))
+		mock.ExpectQuery("SELECT \\* FROM poll").WithArgs(presentation.PresentationID).WillReturnRows(pollRows(previousPoll, poll))
+		mock.ExpectQuery("SELECT \\* FROM option").WithArgs(poll.PollID).
+			WillReturnRows(optionRows(models.OptionDB{Key: "a", Value: "Cats", PollID: poll.PollID}, models.OptionDB{Key: "b", Value: "Dogs", PollID: poll.PollID, Index: 1}))
+		mock.ExpectExec("INSERT INTO vote").WithArgs("b", "client-1", poll.PollID).WillReturnResult(sqlmock.NewResult(0, 1))
+		r := newRequest(http.MethodPost, target, body("b", poll.PollID))
+
+		// Act
+		w := serve(PostPollVote, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Poll Of Another Presentation", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM presentation").WillReturnRows(presentationRows(presentation))
+		mock.ExpectQuery("SELECT \\* FROM poll").WithArgs(presentation.PresentationID).WillReturnRows(pollRows(previousPoll, poll))
+		r := newRequest(http.MethodPost, target, body("a", uuid.New()))
+
+		// Act
+		w := serve(PostPollVote, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "poll_not_found", problemCode(t, w))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Poll Not Current", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM presentation").WillReturnRows(presentationRows(presentation))
+		mock.ExpectQuery("SELECT \\* FROM poll").WithArgs(presentation.PresentationID).WillReturnRows(pollRows(previousPoll, poll))
+		r := newRequest(http.MethodPost, target, body("a", previousPoll.PollID))
+
+		// Act
+		w := serve(PostPollVote, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "poll_not_current", problemCode(t, w))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Poll Closed", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		closed := poll
+		closed.Closed = true
+		mock.ExpectQuery("SELECT \\* FROM presentation").WillReturnRows(presentationRows(presentation))
+		mock.ExpectQuery("SELECT \\* FROM poll").WithArgs(presentation.PresentationID).WillReturnRows(pollRows(previousPoll, closed))
+		r := newRequest(http.MethodPost, target, body("a", poll.PollID))
+
+		// Act
+		w := serve(PostPollVote, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "poll_closed", problemCode(t, w))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Option", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM presentation").WillReturnRows(presentationRows(presentation))
+		mock.ExpectQuery("SELECT \\* FROM poll").WithArgs(presentation.PresentationID).WillReturnRows(pollRows(previousPoll, poll))
+		mock.ExpectQuery("SELECT \\* FROM option").WithArgs(poll.PollID).
+			WillReturnRows(optionRows(models.OptionDB{Key: "a", Value: "Cats", PollID: poll.PollID}))
+		r := newRequest(http.MethodPost, target, body("z", poll.PollID))
+
+		// Act
+		w := serve(PostPollVote, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "validation_failed", problemCode(t, w))
+		assert.Contains(t, w.Body.String(), `"field":"key"`)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetPollVotes(t *testing.T) {
+	pattern := "/presentations/{presentation_id}/polls/{poll_id}/votes"
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		presentationID, pollID := uuid.New(), uuid.New()
+		mock.ExpectQuery("SELECT \\* FROM poll").WithArgs(presentationID).
+			WillReturnRows(pollRows(models.PollDB{PollID: pollID, PresentationID: presentationID}))
+		mock.ExpectQuery("SELECT key, client_id, poll_id FROM vote").WithArgs(pollID).
+			WillReturnRows(sqlmock.NewRows([]string{"key", "client_id", "poll_id"}).AddRow("a", "client-1", pollID))
+		r := newRequest(http.MethodGet, fmt.Sprintf("/presentations/%s/polls/%s/votes", presentationID, pollID), "")
+
+		// Act
+		w := serve(GetPollVotes, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, fmt.Sprintf(`[{"key":"a","client_id":"client-1","poll_id":"%s"}]`, pollID), w.Body.String())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Poll Of Another Presentation", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		presentationID, foreignPollID := uuid.New(), uuid.New()
+		mock.ExpectQuery("SELECT \\* FROM poll").WithArgs(presentationID).
+			WillReturnRows(pollRows(models.PollDB{PollID: uuid.New(), PresentationID: presentationID}))
+		r := newRequest(http.MethodGet, fmt.Sprintf("/presentations/%s/polls/%s/votes", presentationID, foreignPollID), "")
+
+		// Act
+		w := serve(GetPollVotes, pattern, r)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "poll_not_found", problemCode(t, w))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
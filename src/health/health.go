@@ -0,0 +1,91 @@
+// Package health runs the dependency checks behind the readiness probe.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+)
+
+// Check is a named dependency check. Run should give up once ctx is done, a
+// check that does not is reported as failed after the timeout anyway. An
+// informational check that fails is reported with StatusWarn and leaves the
+// report ok.
+type Check struct {
+	Name          string
+	Run           func(ctx context.Context) error
+	Informational bool
+}
+
+// Result is the outcome of a check. Error is kept out of the JSON, as probes
+// are unauthenticated and errors may name internal hosts.
+type Result struct {
+	Status     string  `json:"status"`
+	DurationMS float64 `json:"duration_ms"`
+	Error      error   `json:"-"`
+}
+
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+// Run runs all checks concurrently, each limited to timeout, and reports ok
+// only if every check that is not informational passed.
+func Run(ctx context.Context, timeout time.Duration, checks ...Check) Report {
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(checks))}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			result := run(ctx, timeout, check)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			report.Checks[check.Name] = result
+			if result.Status == StatusFail {
+				report.Status = StatusFail
+			}
+		}(check)
+	}
+	wg.Wait()
+
+	return report
+}
+
+func run(ctx context.Context, timeout time.Duration, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Run(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := Result{Status: StatusOK, DurationMS: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		result.Status = StatusFail
+		if check.Informational {
+			result.Status = StatusWarn
+		}
+		result.Error = err
+	}
+	return result
+}
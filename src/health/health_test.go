@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportJSON(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		report := Report{Status: StatusFail, Checks: map[string]Result{
+			"database": {Status: StatusFail, DurationMS: 1.5, Error: errors.New("dial tcp db.internal:5432: connection refused")},
+		}}
+
+		// Act
+		body, err := json.Marshal(report)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"status":"fail","checks":{"database":{"status":"fail","duration_ms":1.5}}}`, string(body))
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		check := Check{Name: "database", Run: func(context.Context) error { return nil }}
+
+		// Act
+		report := Run(context.Background(), time.Second, check)
+
+		// Assert
+		assert.Equal(t, StatusOK, report.Status)
+		assert.Equal(t, StatusOK, report.Checks["database"].Status)
+	})
+
+	t.Run("Failing Check", func(t *testing.T) {
+		// Arrange
+		passing := Check{Name: "database", Run: func(context.Context) error { return nil }}
+		failing := Check{Name: "upstream", Run: func(context.Context) error { return errors.New("connection refused") }}
+
+		// Act
+		report := Run(context.Background(), time.Second, passing, failing)
+
+		// Assert
+		assert.Equal(t, StatusFail, report.Status)
+		assert.Equal(t, StatusOK, report.Checks["database"].Status)
+		assert.Equal(t, StatusFail, report.Checks["upstream"].Status)
+		assert.EqualError(t, report.Checks["upstream"].Error, "connection refused")
+	})
+
+	t.Run("Failing Informational Check", func(t *testing.T) {
+		// Arrange
+		passing := Check{Name: "database", Run: func(context.Context) error { return nil }}
+		failing := Check{Name: "upstream", Run: func(context.Context) error { return errors.New("connection refused") }, Informational: true}
+
+		// Act
+		report := Run(context.Background(), time.Second, passing, failing)
+
+		// Assert
+		assert.Equal(t, StatusOK, report.Status)
+		assert.Equal(t, StatusWarn, report.Checks["upstream"].Status)
+		assert.EqualError(t, report.Checks["upstream"].Error, "connection refused")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		// Arrange
+		block := make(chan struct{})
+		defer close(block)
+		check := Check{Name: "upstream", Run: func(context.Context) error {
+			<-block
+			return nil
+		}}
+
+		// Act
+		report := Run(context.Background(), 10*time.Millisecond, check)
+
+		// Assert
+		assert.Equal(t, StatusFail, report.Status)
+		assert.ErrorIs(t, report.Checks["upstream"].Error, context.DeadlineExceeded)
+	})
+}
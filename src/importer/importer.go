@@ -0,0 +1,169 @@
+// Package importer turns YAML, JSON and Markdown files into presentations,
+// reporting every problem found together with the line it occurs on.
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"interactive-presentation/src/models"
+	"interactive-presentation/src/validation"
+)
+
+const (
+	FormatYAML     = "yaml"
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+)
+
+type LineError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+type Errors []LineError
+
+func (e Errors) Error() string {
+	var messages []string
+	for _, lineError := range e {
+		messages = append(messages, fmt.Sprintf("line %d: %s", lineError.Line, lineError.Message))
+	}
+	return strings.Join(messages, "\n")
+}
+
+type poll struct {
+	line     int
+	question string
+	options  []option
+}
+
+type option struct {
+	line  int
+	key   string
+	value string
+}
+
+// FormatFromFilename derives the import format from a file extension.
+func FormatFromFilename(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".json":
+		return FormatJSON, nil
+	case ".md", ".markdown":
+		return FormatMarkdown, nil
+	default:
+		return "", fmt.Errorf("unsupported file extension: %s", filepath.Ext(filename))
+	}
+}
+
+// Parse reads a presentation in the given format. When the file is invalid the
+// returned error is of type Errors.
+func Parse(format string, data []byte) (models.Presentation, error) {
+	var polls []poll
+	var errs Errors
+	switch format {
+	case FormatYAML:
+		polls, errs = parseYAML(data)
+	case FormatJSON:
+		polls, errs = parseJSON(data)
+	case FormatMarkdown:
+		polls, errs = parseMarkdown(data)
+	default:
+		return models.Presentation{}, fmt.Errorf("unsupported format: %s", format)
+	}
+	if len(errs) > 0 {
+		return models.Presentation{}, errs
+	}
+
+	var presentation models.Presentation
+	for _, p := range polls {
+		modelPoll := models.Poll{Question: p.question}
+		for _, o := range p.options {
+			modelPoll.Options = append(modelPoll.Options, models.Option{Key: o.key, Value: o.value})
+		}
+		presentation.Polls = append(presentation.Polls, modelPoll)
+	}
+
+	// The presentation is held to the same rules as one sent to the API, only
+	// reported by line.
+	var fieldErrors validation.Errors
+	if err := validation.Validate(presentation); err != nil {
+		if !errors.As(err, &fieldErrors) {
+			return models.Presentation{}, err
+		}
+		return models.Presentation{}, lineErrors(fieldErrors, polls)
+	}
+	return presentation, nil
+}
+
+// fieldPath matches the paths of fields of a presentation reported by the
+// validation package, e.g. polls[1].options[0].key.
+var fieldPath = regexp.MustCompile(`^polls(?:\[(\d+)\](?:\.options(?:\[(\d+)\])?)?)?`)
+
+// lineErrors turns the errors of validating a presentation into errors on the
+// lines of the polls and options they concern.
+func lineErrors(fieldErrors validation.Errors, polls []poll) Errors {
+	var errs Errors
+	for _, fieldError := range fieldErrors {
+		message := fieldError.Message
+		if path, found := strings.CutPrefix(message, "duplicates "); found {
+			message = fmt.Sprintf("duplicates the one on line %d", fieldLine(path, polls))
+		}
+		errs = append(errs, LineError{Line: fieldLine(fieldError.Field, polls), Message: fieldLabel(fieldError.Field) + " " + message})
+	}
+	return errs
+}
+
+// fieldLine returns the line of the poll or option a field path points to.
+// Errors of the whole list of polls, e.g. too many of them, point to the last
+// poll.
+func fieldLine(path string, polls []poll) int {
+	match := fieldPath.FindStringSubmatch(path)
+	if match == nil || len(polls) == 0 {
+		return 1
+	}
+	if match[1] == "" {
+		return polls[len(polls)-1].line
+	}
+
+	pollIndex, _ := strconv.Atoi(match[1])
+	if pollIndex >= len(polls) {
+		return 1
+	}
+	p := polls[pollIndex]
+	if match[2] == "" {
+		return p.line
+	}
+	optionIndex, _ := strconv.Atoi(match[2])
+	if optionIndex >= len(p.options) {
+		return p.line
+	}
+	return p.options[optionIndex].line
+}
+
+// fieldLabel names the field a path points to the way a file author would.
+func fieldLabel(path string) string {
+	name := path[strings.LastIndex(path, ".")+1:]
+	switch name {
+	case "key", "value":
+		return "option " + name
+	default:
+		return name
+	}
+}
+
+// generatedKey returns the key given to the option at index when the file
+// does not name one: A to Z, then AA, AB and so on.
+func generatedKey(index int) string {
+	key := ""
+	for index >= 0 {
+		key = string(rune('A'+index%26)) + key
+		index = index/26 - 1
+	}
+	return key
+}
@@ -0,0 +1,220 @@
+package importer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"interactive-presentation/src/models"
+)
+
+var expected = models.Presentation{
+	Polls: []models.Poll{
+		{
+			Question: "What's your favorite pet?",
+			Options: []models.Option{
+				{Key: "A", Value: "Dog"},
+				{Key: "B", Value: "Cat"},
+			},
+		},
+		{
+			Question: "Which country would you like to visit?",
+			Options: []models.Option{
+				{Key: "AR", Value: "Argentina"},
+				{Key: "AT", Value: "Austria"},
+			},
+		},
+	},
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Act
+		format, err := FormatFromFilename("workshop.YML")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, FormatYAML, format)
+	})
+
+	t.Run("Unsupported Extension", func(t *testing.T) {
+		// Act
+		_, err := FormatFromFilename("workshop.txt")
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestParseYAML(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		data := []byte(`polls:
+  - question: What's your favorite pet?
+    options:
+      - Dog
+      - Cat
+  - question: Which country would you like to visit?
+    options:
+      AR: Argentina
+      AT: Austria
+`)
+
+		// Act
+		presentation, err := Parse(FormatYAML, data)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, presentation)
+	})
+
+	t.Run("Line Numbered Errors", func(t *testing.T) {
+		// Arrange
+		data := []byte(`polls:
+  - question: What's your favorite pet?
+    options:
+      - key: A
+        value: Dog
+      - key: A
+        value: Cat
+  - question: ""
+    options: [Yes]
+`)
+
+		// Act
+		_, err := Parse(FormatYAML, data)
+
+		// Assert
+		var errs Errors
+		assert.True(t, errors.As(err, &errs))
+		assert.Equal(t, Errors{
+			{Line: 6, Message: "option key duplicates the one on line 4"},
+			{Line: 8, Message: "question is required"},
+			{Line: 8, Message: "options must have at least 2 elements"},
+		}, errs)
+	})
+
+	t.Run("Syntax Error", func(t *testing.T) {
+		// Act
+		_, err := Parse(FormatYAML, []byte("polls:\n  - question: [\n"))
+
+		// Assert
+		var errs Errors
+		assert.True(t, errors.As(err, &errs))
+		assert.Equal(t, 2, errs[0].Line)
+	})
+}
+
+func TestParseJSON(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		data := []byte(`{"polls": [
+  {"question": "What's your favorite pet?", "options": [{"key": "A", "value": "Dog"}, {"key": "B", "value": "Cat"}]},
+  {"question": "Which country would you like to visit?", "options": [{"key": "AR", "value": "Argentina"}, {"key": "AT", "value": "Austria"}]}
+]}`)
+
+		// Act
+		presentation, err := Parse(FormatJSON, data)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, presentation)
+	})
+
+	t.Run("Syntax Error", func(t *testing.T) {
+		// Act
+		_, err := Parse(FormatJSON, []byte("{\n  \"polls\": [\n    {\"question\": }\n]}"))
+
+		// Assert
+		var errs Errors
+		assert.True(t, errors.As(err, &errs))
+		assert.Equal(t, 3, errs[0].Line)
+	})
+}
+
+func TestParseMarkdown(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		data := []byte(`# Workshop
+
+Some introduction.
+
+## What's your favorite pet?
+- Dog
+- Cat
+
+## Which country would you like to visit?
+1. Argentina
+2. Austria
+`)
+
+		// Act
+		presentation, err := Parse(FormatMarkdown, data)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "What's your favorite pet?", presentation.Polls[0].Question)
+		assert.Equal(t, []models.Option{{Key: "A", Value: "Argentina"}, {Key: "B", Value: "Austria"}}, presentation.Polls[1].Options)
+	})
+
+	t.Run("Line Numbered Errors", func(t *testing.T) {
+		// Arrange
+		data := []byte(`- Stray option
+## What's your favorite pet?
+- Dog
+- Cat
+## Empty question
+`)
+
+		// Act
+		_, err := Parse(FormatMarkdown, data)
+
+		// Assert
+		var errs Errors
+		assert.True(t, errors.As(err, &errs))
+		assert.Equal(t, Errors{{Line: 1, Message: "option found before the first question heading"}}, errs)
+	})
+
+	t.Run("Question Without Options", func(t *testing.T) {
+		// Arrange
+		data := []byte("## What's your favorite pet?\n- Dog\n- Cat\n## Empty question\n")
+
+		// Act
+		_, err := Parse(FormatMarkdown, data)
+
+		// Assert
+		var errs Errors
+		assert.True(t, errors.As(err, &errs))
+		assert.Equal(t, Errors{{Line: 4, Message: "options must have at least 2 elements"}}, errs)
+	})
+
+	t.Run("Too Many Polls", func(t *testing.T) {
+		// Arrange
+		data := []byte(strings.Repeat("## Question\n- Yes\n- No\n", 101))
+
+		// Act
+		_, err := Parse(FormatMarkdown, data)
+
+		// Assert
+		var errs Errors
+		assert.True(t, errors.As(err, &errs))
+		assert.Equal(t, Errors{{Line: 301, Message: "polls must have at most 100 elements"}}, errs)
+	})
+
+	t.Run("Line Too Long", func(t *testing.T) {
+		// Arrange
+		data := []byte("## What's your favorite pet?\n- Dog\n- " + strings.Repeat("Cat", bufio.MaxScanTokenSize) + "\n- Bird\n")
+
+		// Act
+		_, err := Parse(FormatMarkdown, data)
+
+		// Assert
+		var errs Errors
+		assert.True(t, errors.As(err, &errs))
+		assert.Equal(t, Errors{{Line: 3, Message: fmt.Sprintf("line is longer than %d bytes", bufio.MaxScanTokenSize)}}, errs)
+	})
+}
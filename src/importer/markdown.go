@@ -0,0 +1,100 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type section struct {
+	line    int
+	level   int
+	heading string
+	items   []option
+}
+
+// parseMarkdown reads every heading followed by list items as a poll, with
+// the heading as the question and the list items as options keyed A, B, C
+// and so on. Headings without list items, like a title, are skipped unless
+// they are on the same level as the question headings.
+func parseMarkdown(data []byte) ([]poll, Errors) {
+	var errs Errors
+	var sections []*section
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 1
+	for ; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if level, heading, ok := markdownHeading(line); ok {
+			sections = append(sections, &section{line: lineNumber, level: level, heading: heading})
+			continue
+		}
+
+		item, ok := markdownListItem(line)
+		if !ok {
+			continue
+		}
+		if len(sections) == 0 {
+			errs = append(errs, LineError{Line: lineNumber, Message: "option found before the first question heading"})
+			continue
+		}
+		current := sections[len(sections)-1]
+		current.items = append(current.items, option{line: lineNumber, key: generatedKey(len(current.items)), value: item})
+	}
+	// Scanning stops at the line it failed on, e.g. one that is too long, and
+	// the rest of the file would be missing from the presentation.
+	if err := scanner.Err(); err != nil {
+		message := err.Error()
+		if errors.Is(err, bufio.ErrTooLong) {
+			message = fmt.Sprintf("line is longer than %d bytes", bufio.MaxScanTokenSize)
+		}
+		return nil, append(errs, LineError{Line: lineNumber, Message: message})
+	}
+
+	pollLevel := 0
+	for _, s := range sections {
+		if len(s.items) > 0 {
+			pollLevel = s.level
+			break
+		}
+	}
+
+	var polls []poll
+	for _, s := range sections {
+		if len(s.items) > 0 || s.level == pollLevel {
+			polls = append(polls, poll{line: s.line, question: s.heading, options: s.items})
+		}
+	}
+	return polls, errs
+}
+
+func markdownHeading(line string) (int, string, bool) {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(line) && line[level] != ' ') {
+		return 0, "", false
+	}
+	return level, strings.TrimSpace(strings.TrimRight(line[level:], "#")), true
+}
+
+func markdownListItem(line string) (string, bool) {
+	for _, marker := range []string{"- ", "* ", "+ "} {
+		if strings.HasPrefix(line, marker) {
+			return strings.TrimSpace(line[len(marker):]), true
+		}
+	}
+
+	digits := 0
+	for digits < len(line) && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && digits+1 < len(line) && (line[digits] == '.' || line[digits] == ')') && line[digits+1] == ' ' {
+		return strings.TrimSpace(line[digits+2:]), true
+	}
+	return "", false
+}
@@ -0,0 +1,144 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseYAML expects a mapping with a polls sequence. Every poll is a mapping
+// with a question and options, given either as a sequence of plain values or
+// key/value mappings, or as a mapping from key to value.
+func parseYAML(data []byte) ([]poll, Errors) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, Errors{yamlError(err)}
+	}
+	if len(document.Content) == 0 {
+		return nil, Errors{{Line: 1, Message: "file is empty"}}
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, Errors{{Line: root.Line, Message: "expected a mapping with a polls list"}}
+	}
+
+	var errs Errors
+	var polls []poll
+	pollsNode := mappingValue(root, "polls")
+	if pollsNode == nil {
+		return nil, Errors{{Line: root.Line, Message: "polls is missing"}}
+	}
+	if pollsNode.Kind != yaml.SequenceNode {
+		return nil, Errors{{Line: pollsNode.Line, Message: "polls must be a list"}}
+	}
+
+	for _, pollNode := range pollsNode.Content {
+		if pollNode.Kind != yaml.MappingNode {
+			errs = append(errs, LineError{Line: pollNode.Line, Message: "poll must be a mapping with a question and options"})
+			continue
+		}
+
+		p := poll{line: pollNode.Line}
+		if questionNode := mappingValue(pollNode, "question"); questionNode == nil {
+			errs = append(errs, LineError{Line: pollNode.Line, Message: "question is missing"})
+		} else if questionNode.Kind != yaml.ScalarNode {
+			errs = append(errs, LineError{Line: questionNode.Line, Message: "question must be text"})
+		} else {
+			p.line = questionNode.Line
+			p.question = strings.TrimSpace(questionNode.Value)
+		}
+
+		optionsNode := mappingValue(pollNode, "options")
+		switch {
+		case optionsNode == nil:
+			errs = append(errs, LineError{Line: pollNode.Line, Message: "options are missing"})
+		case optionsNode.Kind == yaml.SequenceNode:
+			for i, optionNode := range optionsNode.Content {
+				o, err := parseYAMLOption(optionNode, i)
+				if err != nil {
+					errs = append(errs, *err)
+					continue
+				}
+				p.options = append(p.options, o)
+			}
+		case optionsNode.Kind == yaml.MappingNode:
+			for i := 0; i+1 < len(optionsNode.Content); i += 2 {
+				keyNode, valueNode := optionsNode.Content[i], optionsNode.Content[i+1]
+				if valueNode.Kind != yaml.ScalarNode {
+					errs = append(errs, LineError{Line: valueNode.Line, Message: "option value must be text"})
+					continue
+				}
+				p.options = append(p.options, option{line: keyNode.Line, key: strings.TrimSpace(keyNode.Value), value: strings.TrimSpace(valueNode.Value)})
+			}
+		default:
+			errs = append(errs, LineError{Line: optionsNode.Line, Message: "options must be a list or a mapping"})
+		}
+
+		polls = append(polls, p)
+	}
+
+	return polls, errs
+}
+
+func parseYAMLOption(optionNode *yaml.Node, index int) (option, *LineError) {
+	switch optionNode.Kind {
+	case yaml.ScalarNode:
+		return option{line: optionNode.Line, key: generatedKey(index), value: strings.TrimSpace(optionNode.Value)}, nil
+	case yaml.MappingNode:
+		o := option{line: optionNode.Line}
+		keyNode := mappingValue(optionNode, "key")
+		valueNode := mappingValue(optionNode, "value")
+		if keyNode == nil || keyNode.Kind != yaml.ScalarNode || valueNode == nil || valueNode.Kind != yaml.ScalarNode {
+			return option{}, &LineError{Line: optionNode.Line, Message: "option must have a key and a value"}
+		}
+		o.key = strings.TrimSpace(keyNode.Value)
+		o.value = strings.TrimSpace(valueNode.Value)
+		return o, nil
+	default:
+		return option{}, &LineError{Line: optionNode.Line, Message: "option must be text or a mapping with a key and a value"}
+	}
+}
+
+// parseJSON checks the syntax with encoding/json, which reports precise
+// offsets, and then reads the document as YAML, of which JSON is a subset, to
+// get line numbers for the validation errors.
+func parseJSON(data []byte) ([]poll, Errors) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		var syntaxError *json.SyntaxError
+		if errors.As(err, &syntaxError) {
+			return nil, Errors{{Line: lineAtOffset(data, syntaxError.Offset), Message: syntaxError.Error()}}
+		}
+		return nil, Errors{{Line: 1, Message: err.Error()}}
+	}
+	return parseYAML(data)
+}
+
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func yamlError(err error) LineError {
+	var line int
+	message := strings.TrimPrefix(err.Error(), "yaml: ")
+	if _, scanErr := fmt.Sscanf(message, "line %d:", &line); scanErr == nil {
+		return LineError{Line: line, Message: strings.TrimSpace(message[strings.Index(message, ":")+1:])}
+	}
+	return LineError{Line: 1, Message: message}
+}
+
+func lineAtOffset(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return strings.Count(string(data[:offset]), "\n") + 1
+}
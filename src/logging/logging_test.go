@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		var requestID string
+		handler := RequestID(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			requestID = RequestIDFromContext(r.Context())
+		}))
+		recorder := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		// Assert
+		assert.NotEmpty(t, requestID)
+		assert.Equal(t, requestID, recorder.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("Client Request ID", func(t *testing.T) {
+		// Arrange
+		handler := RequestID(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set(RequestIDHeader, "abc-123")
+
+		// Act
+		handler.ServeHTTP(recorder, request)
+
+		// Assert
+		assert.Equal(t, "abc-123", recorder.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("Invalid Client Request ID", func(t *testing.T) {
+		// Arrange
+		handler := RequestID(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set(RequestIDHeader, strings.Repeat("a", 200))
+
+		// Act
+		handler.ServeHTTP(recorder, request)
+
+		// Assert
+		assert.Len(t, recorder.Header().Get(RequestIDHeader), 36)
+	})
+}
+
+func TestNew(t *testing.T) {
+	// Arrange
+	var buffer bytes.Buffer
+	logger := New(&buffer, slog.LevelInfo)
+	r := chi.NewRouter()
+	r.Use(RequestID)
+	r.Get("/presentations/{presentation_id}/polls/{poll_id}", func(_ http.ResponseWriter, r *http.Request) {
+		logger.ErrorContext(r.Context(), "something failed")
+	})
+	request := httptest.NewRequest(http.MethodGet, "/presentations/p1/polls/q1", nil)
+	request.Header.Set(RequestIDHeader, "abc-123")
+
+	// Act
+	r.ServeHTTP(httptest.NewRecorder(), request)
+
+	// Assert
+	var line map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buffer.Bytes(), &line))
+	assert.Equal(t, "something failed", line["msg"])
+	assert.Equal(t, "ERROR", line["level"])
+	assert.Equal(t, "abc-123", line["request_id"])
+	assert.Equal(t, "p1", line["presentation_id"])
+	assert.Equal(t, "q1", line["poll_id"])
+}
@@ -0,0 +1,135 @@
+// Package metrics collects the service's Prometheus metrics and exposes them
+// in the Prometheus text format.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "presentation"
+
+// Registry holds every metric of the service, next to the Go runtime and
+// process collectors.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by method, route pattern and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by method and route pattern.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// VotesRecorded counts stored votes, its rate gives the votes per second.
+	VotesRecorded = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "votes_recorded_total",
+		Help:      "Number of votes recorded.",
+	})
+
+	// LiveConnections is the number of open server-sent event streams.
+	LiveConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "live_connections",
+		Help:      "Number of open live connections by stream.",
+	}, []string{"stream"})
+
+	upstreamRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upstream_request_duration_seconds",
+		Help:      "Latency of calls to the upstream presentation service by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "status"})
+
+	upstreamRequestFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upstream_request_failures_total",
+		Help:      "Number of calls to the upstream presentation service that failed or returned a server error.",
+	}, []string{"method"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpRequestDuration,
+		VotesRecorded,
+		LiveConnections,
+		upstreamRequestDuration,
+		upstreamRequestFailures,
+	)
+}
+
+// Handler serves the collected metrics.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// RegisterDB adds the connection pool statistics of db.
+func RegisterDB(db *sql.DB) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, "presentation"))
+}
+
+// Middleware records the count and latency of every request labeled with its
+// chi route pattern, so requests for different presentations share a series.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		route := "unmatched"
+		if routeContext := chi.RouteContext(r.Context()); routeContext != nil && routeContext.RoutePattern() != "" {
+			route = routeContext.RoutePattern()
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		httpRequests.WithLabelValues(r.Method, route, strconv.Itoa(status)).Inc()
+		httpRequestDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
+
+type upstreamTransport struct {
+	next http.RoundTripper
+}
+
+// UpstreamTransport wraps next to record the latency and failures of calls to
+// the upstream presentation service.
+func UpstreamTransport(next http.RoundTripper) http.RoundTripper {
+	return upstreamTransport{next: next}
+}
+
+func (t upstreamTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(r)
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	upstreamRequestDuration.WithLabelValues(r.Method, status).Observe(time.Since(start).Seconds())
+	if err != nil || resp.StatusCode >= http.StatusInternalServerError {
+		upstreamRequestFailures.WithLabelValues(r.Method).Inc()
+	}
+	return resp, err
+}
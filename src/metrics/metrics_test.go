@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		r := chi.NewRouter()
+		r.Use(Middleware)
+		r.Get("/presentations/{presentation_id}", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+		before := testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "/presentations/{presentation_id}", "418"))
+
+		// Act
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/presentations/1", nil))
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/presentations/2", nil))
+
+		// Assert
+		after := testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "/presentations/{presentation_id}", "418"))
+		assert.Equal(t, 2.0, after-before)
+	})
+}
+
+func TestUpstreamTransport(t *testing.T) {
+	t.Run("Failure Case", func(t *testing.T) {
+		// Arrange
+		transport := UpstreamTransport(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		}))
+		before := testutil.ToFloat64(upstreamRequestFailures.WithLabelValues(http.MethodPost))
+
+		// Act
+		_, err := transport.RoundTrip(httptest.NewRequest(http.MethodPost, "http://upstream/presentations", nil))
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, 1.0, testutil.ToFloat64(upstreamRequestFailures.WithLabelValues(http.MethodPost))-before)
+	})
+}
+
+func TestHandler(t *testing.T) {
+	// Arrange
+	VotesRecorded.Inc()
+	recorder := httptest.NewRecorder()
+
+	// Act
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "presentation_votes_recorded_total")
+}
@@ -0,0 +1,12 @@
+package models
+
+import "github.com/google/uuid"
+
+type Blocklist struct {
+	Words []string `json:"words"`
+}
+
+type BlocklistDB struct {
+	PresentationID uuid.UUID `db:"presentation_id"`
+	Word           string    `db:"word"`
+}
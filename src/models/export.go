@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VoteExport struct {
+	PollIndex int       `json:"poll_index"`
+	PollID    uuid.UUID `json:"poll_id"`
+	Question  string    `json:"question"`
+	Key       string    `json:"key"`
+	Value     string    `json:"value"`
+	ClientID  string    `json:"client_id"`
+	VoteID    int64     `json:"-"`
+	CreatedAt time.Time `json:"-"`
+}
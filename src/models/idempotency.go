@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// IdempotencyKeyDB is a request made with an Idempotency-Key header and, once
+// it completed, the response replayed for retries. Status is 0 while the
+// request is still being processed.
+type IdempotencyKeyDB struct {
+	Key         string    `db:"key"`
+	Path        string    `db:"path"`
+	RequestHash string    `db:"request_hash"`
+	Status      int       `db:"status"`
+	ContentType string    `db:"content_type"`
+	Body        []byte    `db:"body"`
+	CreatedAt   time.Time `db:"created_at"`
+}
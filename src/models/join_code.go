@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JoinCode struct {
+	Code           string    `json:"code" db:"code"`
+	PresentationID uuid.UUID `json:"presentation_id" db:"presentation_id"`
+	ExpiresAt      time.Time `json:"expires_at" db:"expires_at"`
+}
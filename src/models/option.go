@@ -3,8 +3,8 @@ package models
 import "github.com/google/uuid"
 
 type Option struct {
-	Key   string `json:"key"`
-	Value string `json:"value"`
+	Key   string `json:"key" validate:"required,max=255"`
+	Value string `json:"value" validate:"required,max=255"`
 }
 
 type OptionDB struct {
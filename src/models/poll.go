@@ -4,8 +4,8 @@ import "github.com/google/uuid"
 
 type Poll struct {
 	PollID   uuid.UUID `json:"poll_id"`
-	Question string    `json:"question"`
-	Options  []Option  `json:"options"`
+	Question string    `json:"question" validate:"required,max=255"`
+	Options  []Option  `json:"options" validate:"min=2,unique=Key"`
 }
 
 type PollDB struct {
@@ -13,4 +13,65 @@ type PollDB struct {
 	Question       string    `db:"question"`
 	PresentationID uuid.UUID `db:"presentation_id"`
 	Index          int       `db:"index"`
+	Closed         bool      `db:"closed"`
+}
+
+const (
+	PollOpen   = "open"
+	PollClosed = "closed"
+)
+
+type PollIndex struct {
+	Index *int `json:"index"`
+}
+
+type PollStatus struct {
+	PollID uuid.UUID `json:"poll_id"`
+	Index  int       `json:"index"`
+	Polls  int       `json:"polls"`
+	Status string    `json:"status"`
+}
+
+type OptionResult struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Votes int    `json:"votes"`
+}
+
+type PollResults struct {
+	PollID   uuid.UUID      `json:"poll_id"`
+	Question string         `json:"question"`
+	Votes    int            `json:"votes"`
+	Options  []OptionResult `json:"options"`
+}
+
+// PollV2 is the current poll as returned by the v2 API, with its position in
+// the presentation, whether it accepts votes and the votes recorded so far.
+type PollV2 struct {
+	PollID   uuid.UUID        `json:"poll_id"`
+	Question string           `json:"question"`
+	Index    int              `json:"index"`
+	Polls    int              `json:"polls"`
+	Status   string           `json:"status"`
+	Votes    int              `json:"votes"`
+	Options  []OptionResultV2 `json:"options"`
+}
+
+type OptionResultV2 struct {
+	Key        string  `json:"key"`
+	Value      string  `json:"value"`
+	Votes      int     `json:"votes"`
+	Percentage float64 `json:"percentage"`
+}
+
+// PollResultsV2 extends PollResults with the state of the poll, the number of
+// clients that voted and the share of every option.
+type PollResultsV2 struct {
+	PollID       uuid.UUID        `json:"poll_id"`
+	Question     string           `json:"question"`
+	Index        int              `json:"index"`
+	Status       string           `json:"status"`
+	Votes        int              `json:"votes"`
+	Participants int              `json:"participants"`
+	Options      []OptionResultV2 `json:"options"`
 }
@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PollShownDB struct {
+	PresentationID uuid.UUID `db:"presentation_id"`
+	PollIndex      int       `db:"poll_index"`
+	ShownAt        time.Time `db:"shown_at"`
+}
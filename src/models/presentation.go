@@ -5,10 +5,29 @@ import "github.com/google/uuid"
 type Presentation struct {
 	PresentationID   uuid.UUID `json:"presentation_id"`
 	CurrentPollIndex int       `json:"current_poll_index"`
-	Polls            []Poll    `json:"polls"`
+	Polls            []Poll    `json:"polls" validate:"required,max=100"`
 }
 
+// CreatedPresentation is the upstream response to creating a presentation
+// extended with its presenter token and join code.
+type CreatedPresentation struct {
+	PresentationID uuid.UUID `json:"presentation_id"`
+	PresenterToken string    `json:"presenter_token"`
+	JoinCode       string    `json:"join_code"`
+}
+
+// PresentationDB is a stored presentation. Version is bumped on every change
+// to its current poll, poll status or votes and identifies the state clients
+// have seen in ETags.
 type PresentationDB struct {
 	PresentationID   uuid.UUID `db:"presentation_id"`
 	CurrentPollIndex int       `db:"current_poll_index"`
+	Version          int       `db:"version"`
+}
+
+type PresentationSummary struct {
+	PresentationID   uuid.UUID `json:"presentation_id"`
+	CurrentPollIndex int       `json:"current_poll_index"`
+	Polls            int       `json:"polls"`
+	JoinCode         string    `json:"join_code"`
 }
@@ -0,0 +1,8 @@
+package models
+
+import "github.com/google/uuid"
+
+type PresenterTokenDB struct {
+	PresentationID uuid.UUID `db:"presentation_id"`
+	TokenHash      string    `db:"token_hash"`
+}
@@ -0,0 +1,11 @@
+package models
+
+type Reaction struct {
+	ClientID string `json:"client_id"`
+	Emoji    string `json:"emoji"`
+}
+
+type ReactionCounts struct {
+	Second int64          `json:"second"`
+	Counts map[string]int `json:"counts"`
+}
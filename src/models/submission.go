@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SubmissionPending  = "pending"
+	SubmissionApproved = "approved"
+	SubmissionRejected = "rejected"
+)
+
+type Submission struct {
+	SubmissionID uuid.UUID `json:"submission_id" db:"submission_id"`
+	PollID       uuid.UUID `json:"poll_id" db:"poll_id"`
+	ClientID     string    `json:"client_id" db:"client_id"`
+	Text         string    `json:"text" db:"text"`
+	Status       string    `json:"status" db:"status"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+type SubmissionStatus struct {
+	Status string `json:"status"`
+}
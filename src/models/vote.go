@@ -3,7 +3,7 @@ package models
 import "github.com/google/uuid"
 
 type Vote struct {
-	Key      string    `json:"key" db:"key"`
-	ClientID string    `json:"client_id" db:"client_id"`
-	PollID   uuid.UUID `json:"poll_id" db:"poll_id"`
+	Key      string    `json:"key" db:"key" validate:"required,max=255"`
+	ClientID string    `json:"client_id" db:"client_id" validate:"required,max=255"`
+	PollID   uuid.UUID `json:"poll_id" db:"poll_id" validate:"required"`
 }
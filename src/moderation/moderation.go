@@ -0,0 +1,83 @@
+package moderation
+
+import (
+	"strings"
+	"unicode"
+)
+
+// defaultBlocklist is applied to every presentation in addition to the words
+// configured for it.
+var defaultBlocklist = []string{
+	"arsehole",
+	"asshole",
+	"bastard",
+	"bitch",
+	"bullshit",
+	"cunt",
+	"fuck",
+	"fucking",
+	"motherfucker",
+	"shit",
+	"wanker",
+}
+
+// NormalizeWord lower-cases a blocklist entry and collapses its spaces so
+// that stored entries compare equal to the words extracted from a submission.
+// Entries may be phrases of several words.
+func NormalizeWord(word string) string {
+	return strings.Join(strings.Fields(strings.ToLower(word)), " ")
+}
+
+// IsBlocked reports whether text contains any word or phrase from the default
+// blocklist or from the given presentation specific entries. Matching is done
+// on whole words, a phrase matches when its words follow each other in text,
+// and is case-insensitive.
+func IsBlocked(text string, words []string) bool {
+	blocked := make(map[string]struct{}, len(defaultBlocklist)+len(words))
+	for _, word := range defaultBlocklist {
+		blocked[word] = struct{}{}
+	}
+	var phrases [][]string
+	for _, word := range words {
+		switch phrase := splitWords(word); len(phrase) {
+		case 0:
+		case 1:
+			blocked[phrase[0]] = struct{}{}
+		default:
+			phrases = append(phrases, phrase)
+		}
+	}
+
+	fields := splitWords(text)
+	for i, field := range fields {
+		if _, found := blocked[field]; found {
+			return true
+		}
+		for _, phrase := range phrases {
+			if hasPrefix(fields[i:], phrase) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitWords returns the lower-cased words of text, separated by anything but
+// letters and numbers.
+func splitWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func hasPrefix(fields []string, phrase []string) bool {
+	if len(fields) < len(phrase) {
+		return false
+	}
+	for i, word := range phrase {
+		if fields[i] != word {
+			return false
+		}
+	}
+	return true
+}
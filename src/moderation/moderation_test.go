@@ -0,0 +1,67 @@
+package moderation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBlocked(t *testing.T) {
+	t.Run("Clean Text", func(t *testing.T) {
+		// Act
+		blocked := IsBlocked("What a great talk!", nil)
+
+		// Assert
+		assert.False(t, blocked)
+	})
+
+	t.Run("Default Blocklist", func(t *testing.T) {
+		// Act
+		blocked := IsBlocked("This is SHIT.", nil)
+
+		// Assert
+		assert.True(t, blocked)
+	})
+
+	t.Run("Presentation Blocklist", func(t *testing.T) {
+		// Act
+		blocked := IsBlocked("Ask about competitor pricing", []string{" Competitor "})
+
+		// Assert
+		assert.True(t, blocked)
+	})
+
+	t.Run("Partial Word Is Not Blocked", func(t *testing.T) {
+		// Act
+		blocked := IsBlocked("Scunthorpe is a town", nil)
+
+		// Assert
+		assert.False(t, blocked)
+	})
+
+	t.Run("Presentation Phrase", func(t *testing.T) {
+		// Act
+		blocked := IsBlocked("Is the Acme  Corp. deal done?", []string{"acme corp"})
+
+		// Assert
+		assert.True(t, blocked)
+	})
+
+	t.Run("Phrase Words Apart Are Not Blocked", func(t *testing.T) {
+		// Act
+		blocked := IsBlocked("Acme sells to every corp", []string{"acme corp"})
+
+		// Assert
+		assert.False(t, blocked)
+	})
+}
+
+func TestNormalizeWord(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Act
+		word := NormalizeWord("  Acme \t Corp ")
+
+		// Assert
+		assert.Equal(t, "acme corp", word)
+	})
+}
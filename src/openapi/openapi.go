@@ -0,0 +1,241 @@
+// Package openapi describes the HTTP API of the service as an OpenAPI 3
+// document. Request and response schemas are derived from the models by
+// reflection, so they follow the models as they change.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"interactive-presentation/src/utilities"
+)
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem maps lower case HTTP methods to the operations of a path.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Scheme      string `json:"scheme,omitempty"`
+	In          string `json:"in,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+var (
+	pathParameter  = regexp.MustCompile(`\{([a-z_]+)\}`)
+	idempotencyKey = Parameter{
+		Name:        "Idempotency-Key",
+		In:          "header",
+		Description: "Makes the request safe to retry, the first response to a key is replayed for retries with the same body",
+		Schema:      &Schema{Type: "string"},
+	}
+	ifNoneMatch = Parameter{
+		Name:        "If-None-Match",
+		In:          "header",
+		Description: "ETag of a previous response, answered with 304 Not Modified while it is still current",
+		Schema:      &Schema{Type: "string"},
+	}
+)
+
+// Build assembles the document describing routes.
+func Build(routes []Route) Document {
+	components := schemas{}
+	problem := components.of(reflect.TypeOf(utilities.Problem{}))
+
+	document := Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title: "Interactive presentation",
+			Description: "Polls, votes, submissions and reactions for interactive presentations. The API is served under /v2, " +
+				"the deprecated v1 API under /v1 and without a prefix. Errors are answered with RFC 7807 problem details.",
+			Version: "2.0.0",
+		},
+		Paths: make(map[string]PathItem),
+		Components: Components{
+			Schemas: components,
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearer":         {Type: "http", Scheme: "bearer", Description: "Presenter token of the presentation"},
+				"presenterToken": {Type: "apiKey", In: "header", Name: "X-Presenter-Token", Description: "Presenter token of the presentation"},
+			},
+		},
+	}
+
+	for _, route := range routes {
+		path := Path(route.Pattern)
+		operation := &Operation{
+			OperationID: operationID(route.Method, path),
+			Summary:     route.Summary,
+			Tags:        []string{route.Tag},
+			Responses:   map[string]Response{},
+			Deprecated:  route.Deprecated,
+		}
+
+		for _, match := range pathParameter.FindAllStringSubmatch(path, -1) {
+			schema := &Schema{Type: "string"}
+			if strings.HasSuffix(match[1], "_id") {
+				schema.Format = "uuid"
+			}
+			operation.Parameters = append(operation.Parameters, Parameter{Name: match[1], In: "path", Required: true, Schema: schema})
+		}
+		if route.Method == http.MethodPost {
+			operation.Parameters = append(operation.Parameters, idempotencyKey)
+		}
+		if route.Conditional {
+			operation.Parameters = append(operation.Parameters, ifNoneMatch)
+		}
+		operation.Parameters = append(operation.Parameters, route.Query...)
+
+		switch {
+		case route.Body != nil:
+			operation.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{
+				"application/json": {Schema: components.of(reflect.TypeOf(route.Body))},
+			}}
+		case len(route.BodyContent) > 0:
+			operation.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{}}
+			for _, mediaType := range route.BodyContent {
+				operation.RequestBody.Content[mediaType] = MediaType{Schema: &Schema{Type: "string"}}
+			}
+		}
+
+		response := Response{Description: http.StatusText(route.Status)}
+		switch {
+		case route.Response != nil:
+			response.Content = map[string]MediaType{"application/json": {Schema: components.of(reflect.TypeOf(route.Response))}}
+		case len(route.Content) > 0:
+			response.Content = map[string]MediaType{}
+			for _, mediaType := range route.Content {
+				response.Content[mediaType] = MediaType{}
+			}
+		}
+		operation.Responses[strconv.Itoa(route.Status)] = response
+		if route.Conditional {
+			operation.Responses[strconv.Itoa(http.StatusNotModified)] = Response{Description: http.StatusText(http.StatusNotModified)}
+		}
+		operation.Responses["default"] = Response{
+			Description: "Problem details",
+			Content:     map[string]MediaType{utilities.ProblemContentType: {Schema: problem}},
+		}
+
+		if route.Presenter {
+			operation.Security = []map[string][]string{{"bearer": {}}, {"presenterToken": {}}}
+		}
+
+		if document.Paths[path] == nil {
+			document.Paths[path] = PathItem{}
+		}
+		document.Paths[path][strings.ToLower(route.Method)] = operation
+	}
+
+	return document
+}
+
+// Path turns a chi route pattern into an OpenAPI path, naming the wildcard.
+func Path(pattern string) string {
+	if strings.HasSuffix(pattern, "/*") {
+		return strings.TrimSuffix(pattern, "*") + "{path}"
+	}
+	return pattern
+}
+
+// operationID derives a stable identifier such as getPresentationsPollsCurrent
+// from the method and path of an operation.
+func operationID(method string, path string) string {
+	id := strings.ToLower(method)
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") {
+			continue
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return r == '.' || r == '-' || r == '_' }) {
+			id += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	if path == "/" {
+		id += "Root"
+	}
+	return id
+}
+
+var (
+	documentOnce sync.Once
+	documentJSON []byte
+)
+
+// Handler serves the document of Routes as JSON.
+func Handler(w http.ResponseWriter, r *http.Request) {
+	documentOnce.Do(func() {
+		var err error
+		documentJSON, err = json.Marshal(Build(Routes))
+		if err != nil {
+			slog.Error("error encoding OpenAPI document", "error", err)
+		}
+	})
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(documentJSON)
+}
+
+//go:embed docs.html
+var docsPage []byte
+
+// Docs serves a page rendering the document served at /openapi.json.
+func Docs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(docsPage)
+}
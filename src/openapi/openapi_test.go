@@ -0,0 +1,74 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuild(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Act
+		document := Build(Routes)
+
+		// Assert
+		operationIDs := make(map[string]bool)
+		for path, item := range document.Paths {
+			for method, operation := range item {
+				assert.False(t, operationIDs[operation.OperationID], "%s %s reuses operation ID %s", method, path, operation.OperationID)
+				operationIDs[operation.OperationID] = true
+			}
+		}
+		assert.Len(t, operationIDs, len(Routes))
+
+		encoded, err := json.Marshal(document)
+		assert.NoError(t, err)
+		for _, reference := range strings.Split(string(encoded), `"$ref":"#/components/schemas/`)[1:] {
+			name := reference[:strings.Index(reference, `"`)]
+			assert.NotNil(t, document.Components.Schemas[name], "schema %s is referenced but missing", name)
+		}
+	})
+
+	t.Run("Schemas From Models", func(t *testing.T) {
+		// Act
+		document := Build(Routes)
+
+		// Assert
+		poll := document.Components.Schemas["Poll"]
+		assert.Equal(t, []string{"question"}, poll.Required)
+		assert.Equal(t, 255, *poll.Properties["question"].MaxLength)
+		assert.Equal(t, 2, *poll.Properties["options"].MinItems)
+		assert.Equal(t, "#/components/schemas/Option", poll.Properties["options"].Items.Ref)
+		assert.Equal(t, "uuid", poll.Properties["poll_id"].Format)
+
+		operation := document.Paths["/presentations/{presentation_id}/polls/{poll_id}/votes"]["get"]
+		assert.Equal(t, "getPresentationsPollsVotes", operation.OperationID)
+		assert.Len(t, operation.Security, 2)
+		assert.Equal(t, []string{"presentation_id", "poll_id"}, []string{operation.Parameters[0].Name, operation.Parameters[1].Name})
+		assert.Contains(t, document.Paths, "/assets/{path}")
+		assert.Equal(t, "Idempotency-Key", document.Paths["/v2/presentations"]["post"].Parameters[0].Name)
+		assert.Contains(t, document.Paths["/v2/presentations/{presentation_id}/polls/current"]["get"].Responses, "304")
+	})
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+
+		// Act
+		Handler(w, r)
+
+		// Assert
+		var document Document
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &document))
+		assert.Equal(t, "3.0.3", document.OpenAPI)
+	})
+}
@@ -0,0 +1,130 @@
+package openapi
+
+import (
+	"net/http"
+
+	"interactive-presentation/src/health"
+	"interactive-presentation/src/models"
+)
+
+// Route is an operation of the API under its chi route pattern.
+type Route struct {
+	Method  string
+	Pattern string
+	Summary string
+	Tag     string
+	// Presenter routes require the presenter token of the presentation.
+	Presenter bool
+	Query     []Parameter
+	// Body is the model of a JSON request body, BodyContent lists the media
+	// types of a request body that is not JSON.
+	Body        interface{}
+	BodyContent []string
+	Status      int
+	// Response is the model of a JSON response, Content lists the media types
+	// of a response that is not JSON.
+	Response interface{}
+	Content  []string
+	// Deprecated routes belong to the v1 API.
+	Deprecated bool
+	// Conditional routes answer with an ETag and honor If-None-Match.
+	Conditional bool
+}
+
+func query(name string, description string, values ...string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string", Enum: values}}
+}
+
+// Routes lists every route the service registers when all features are
+// enabled. A test in cmd/service keeps it in line with the router.
+var Routes = versionedRoutes()
+
+// operationalRoutes are served without a version prefix.
+var operationalRoutes = []Route{
+	{Method: http.MethodGet, Pattern: "/ping", Summary: "Check that the service is up", Tag: "operations", Status: http.StatusOK, Content: []string{"text/plain"}},
+	{Method: http.MethodGet, Pattern: "/healthz", Summary: "Liveness probe", Tag: "operations", Status: http.StatusOK, Response: health.Report{}},
+	{Method: http.MethodGet, Pattern: "/readyz", Summary: "Readiness probe checking the database, migrations and upstream service", Tag: "operations", Status: http.StatusOK, Response: health.Report{}},
+	{Method: http.MethodGet, Pattern: "/metrics", Summary: "Prometheus metrics", Tag: "operations", Status: http.StatusOK, Content: []string{"text/plain"}},
+	{Method: http.MethodGet, Pattern: "/openapi.json", Summary: "This document", Tag: "operations", Status: http.StatusOK, Content: []string{"application/json"}},
+	{Method: http.MethodGet, Pattern: "/docs", Summary: "API documentation page", Tag: "operations", Status: http.StatusOK, Content: []string{"text/html"}},
+
+	{Method: http.MethodGet, Pattern: "/", Summary: "Audience page", Tag: "web app", Status: http.StatusOK, Content: []string{"text/html"}},
+	{Method: http.MethodGet, Pattern: "/present", Summary: "Presenter page", Tag: "web app", Status: http.StatusOK, Content: []string{"text/html"}},
+	{Method: http.MethodGet, Pattern: "/assets/*", Summary: "Stylesheets and scripts of the web app", Tag: "web app", Status: http.StatusOK, Content: []string{"text/css", "text/javascript"}},
+}
+
+// apiRoutes are served under /v1, /v2 and, for v1, without a prefix.
+var apiRoutes = []Route{
+
+	{Method: http.MethodPost, Pattern: "/presentations", Summary: "Create a presentation", Tag: "presentations", Body: models.Presentation{}, Status: http.StatusCreated, Response: models.CreatedPresentation{}},
+	{Method: http.MethodPost, Pattern: "/presentations/import", Summary: "Create a presentation from a YAML, JSON or Markdown file", Tag: "presentations",
+		Query:       []Parameter{query("format", "Format of the file, derived from the Content-Type when omitted", "yaml", "json", "markdown")},
+		BodyContent: []string{"application/yaml", "application/json", "text/markdown"}, Status: http.StatusCreated, Response: models.CreatedPresentation{}},
+	{Method: http.MethodGet, Pattern: "/presentations/{presentation_id}/export", Summary: "Export every vote of a presentation", Tag: "presentations", Presenter: true,
+		Query:  []Parameter{query("format", "Format of the export, defaults to csv", "csv", "jsonl", "xlsx")},
+		Status: http.StatusOK, Content: []string{"text/csv", "application/x-ndjson", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}},
+	{Method: http.MethodGet, Pattern: "/presentations/{presentation_id}/report", Summary: "Report of the session", Tag: "presentations", Presenter: true,
+		Query:  []Parameter{query("format", "Format of the report, defaults to html", "html", "md")},
+		Status: http.StatusOK, Content: []string{"text/html", "text/markdown"}},
+
+	{Method: http.MethodGet, Pattern: "/join/{code}", Summary: "Resolve a join code, browsers are redirected to the audience page", Tag: "join codes", Status: http.StatusOK, Response: models.JoinCode{}},
+	{Method: http.MethodGet, Pattern: "/presentations/{presentation_id}/join-code", Summary: "Get the join code of a presentation", Tag: "join codes", Status: http.StatusOK, Response: models.JoinCode{}},
+	{Method: http.MethodPost, Pattern: "/presentations/{presentation_id}/join-code", Summary: "Replace the join code of a presentation", Tag: "join codes", Presenter: true, Status: http.StatusCreated, Response: models.JoinCode{}},
+	{Method: http.MethodGet, Pattern: "/presentations/{presentation_id}/qr.png", Summary: "QR code of the join URL as PNG", Tag: "join codes",
+		Query:  []Parameter{query("size", "Width in pixels, 64 to 2048"), query("level", "Error correction level, defaults to M", "L", "M", "Q", "H")},
+		Status: http.StatusOK, Content: []string{"image/png"}},
+	{Method: http.MethodGet, Pattern: "/presentations/{presentation_id}/qr.svg", Summary: "QR code of the join URL as SVG", Tag: "join codes",
+		Query:  []Parameter{query("size", "Width in pixels, 64 to 2048"), query("level", "Error correction level, defaults to M", "L", "M", "Q", "H")},
+		Status: http.StatusOK, Content: []string{"image/svg+xml"}},
+
+	{Method: http.MethodGet, Pattern: "/presentations/{presentation_id}/polls/current", Summary: "Get the current poll", Tag: "polls", Conditional: true, Status: http.StatusOK, Response: models.Poll{}},
+	{Method: http.MethodPut, Pattern: "/presentations/{presentation_id}/polls/current", Summary: "Move to the next poll or the poll at the given index", Tag: "polls", Presenter: true, Body: models.PollIndex{}, Status: http.StatusOK, Response: models.Poll{}},
+	{Method: http.MethodGet, Pattern: "/presentations/{presentation_id}/polls/current/status", Summary: "Get the status of the current poll", Tag: "polls", Status: http.StatusOK, Response: models.PollStatus{}},
+	{Method: http.MethodPut, Pattern: "/presentations/{presentation_id}/polls/current/status", Summary: "Open or close the current poll", Tag: "polls", Presenter: true, Body: models.PollStatus{}, Status: http.StatusNoContent},
+	{Method: http.MethodGet, Pattern: "/presentations/{presentation_id}/polls/{poll_id}/results", Summary: "Vote counts of every option of a poll", Tag: "polls", Conditional: true, Status: http.StatusOK, Response: models.PollResults{}},
+	{Method: http.MethodGet, Pattern: "/presentations/{presentation_id}/polls/{poll_id}/chart.svg", Summary: "Chart of the results of a poll as SVG", Tag: "polls",
+		Query: []Parameter{query("type", "Kind of chart, defaults to bar", "bar", "pie")}, Status: http.StatusOK, Content: []string{"image/svg+xml"}},
+	{Method: http.MethodGet, Pattern: "/presentations/{presentation_id}/polls/{poll_id}/chart.png", Summary: "Chart of the results of a poll as PNG", Tag: "polls",
+		Query: []Parameter{query("type", "Kind of chart, defaults to bar", "bar", "pie")}, Status: http.StatusOK, Content: []string{"image/png"}},
+
+	{Method: http.MethodPost, Pattern: "/presentations/{presentation_id}/polls/current/votes", Summary: "Vote on the current poll", Tag: "votes", Body: models.Vote{}, Status: http.StatusNoContent},
+	{Method: http.MethodGet, Pattern: "/presentations/{presentation_id}/polls/{poll_id}/votes", Summary: "Every vote on a poll", Tag: "votes", Presenter: true, Status: http.StatusOK, Response: []models.Vote{}},
+
+	{Method: http.MethodPost, Pattern: "/presentations/{presentation_id}/polls/current/submissions", Summary: "Submit free text for the current poll", Tag: "submissions", Body: models.Submission{}, Status: http.StatusCreated, Response: models.Submission{}},
+	{Method: http.MethodGet, Pattern: "/presentations/{presentation_id}/polls/{poll_id}/submissions", Summary: "Approved submissions of a poll", Tag: "submissions", Status: http.StatusOK, Response: []models.Submission{}},
+	{Method: http.MethodGet, Pattern: "/presentations/{presentation_id}/submissions", Summary: "Moderation queue of a presentation", Tag: "submissions", Presenter: true,
+		Query:  []Parameter{query("status", "Status of the submissions, defaults to pending", models.SubmissionPending, models.SubmissionApproved, models.SubmissionRejected)},
+		Status: http.StatusOK, Response: []models.Submission{}},
+	{Method: http.MethodPut, Pattern: "/presentations/{presentation_id}/submissions/{submission_id}", Summary: "Approve or reject a submission", Tag: "submissions", Presenter: true, Body: models.SubmissionStatus{}, Status: http.StatusNoContent},
+	{Method: http.MethodGet, Pattern: "/presentations/{presentation_id}/blocklist", Summary: "Blocked words of a presentation", Tag: "submissions", Presenter: true, Status: http.StatusOK, Response: models.Blocklist{}},
+	{Method: http.MethodPut, Pattern: "/presentations/{presentation_id}/blocklist", Summary: "Replace the blocked words of a presentation", Tag: "submissions", Presenter: true, Body: models.Blocklist{}, Status: http.StatusOK, Response: models.Blocklist{}},
+
+	{Method: http.MethodPost, Pattern: "/presentations/{presentation_id}/reactions", Summary: "Send an emoji reaction", Tag: "reactions", Body: models.Reaction{}, Status: http.StatusNoContent},
+	{Method: http.MethodGet, Pattern: "/presentations/{presentation_id}/reactions/stream", Summary: "Server-sent events with the reaction counts of every second", Tag: "reactions", Status: http.StatusOK, Content: []string{"text/event-stream"}},
+}
+
+// v2Responses holds the models of the v2 responses that differ from v1.
+var v2Responses = map[string]interface{}{
+	"GET /presentations/{presentation_id}/polls/current":           models.PollV2{},
+	"PUT /presentations/{presentation_id}/polls/current":           models.PollV2{},
+	"GET /presentations/{presentation_id}/polls/{poll_id}/results": models.PollResultsV2{},
+}
+
+func versionedRoutes() []Route {
+	routes := append([]Route{}, operationalRoutes...)
+	for _, prefix := range []string{"", "/v1"} {
+		for _, route := range apiRoutes {
+			route.Pattern = prefix + route.Pattern
+			route.Deprecated = true
+			routes = append(routes, route)
+		}
+	}
+	for _, route := range apiRoutes {
+		if response, found := v2Responses[route.Method+" "+route.Pattern]; found {
+			route.Response = response
+		}
+		route.Pattern = "/v2" + route.Pattern
+		routes = append(routes, route)
+	}
+	return routes
+}
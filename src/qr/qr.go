@@ -0,0 +1,63 @@
+package qr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	DefaultSize = 256
+	MinSize     = 64
+	MaxSize     = 2048
+)
+
+// ParseLevel maps the error correction level names L, M, Q and H to their
+// qrcode recovery levels. An empty name selects M.
+func ParseLevel(name string) (qrcode.RecoveryLevel, error) {
+	switch strings.ToUpper(name) {
+	case "L":
+		return qrcode.Low, nil
+	case "", "M":
+		return qrcode.Medium, nil
+	case "Q":
+		return qrcode.High, nil
+	case "H":
+		return qrcode.Highest, nil
+	default:
+		return 0, fmt.Errorf("unknown error correction level: %s", name)
+	}
+}
+
+func PNG(content string, size int, level qrcode.RecoveryLevel) ([]byte, error) {
+	code, err := qrcode.New(content, level)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding qr code: %v", err)
+	}
+	return code.PNG(size)
+}
+
+// SVG renders the QR code as a square SVG image of the given size, drawing
+// one path with a unit square per dark module.
+func SVG(content string, size int, level qrcode.RecoveryLevel) ([]byte, error) {
+	code, err := qrcode.New(content, level)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding qr code: %v", err)
+	}
+	bitmap := code.Bitmap()
+
+	var path strings.Builder
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				_, _ = fmt.Fprintf(&path, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	var svg strings.Builder
+	_, _ = fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, len(bitmap), len(bitmap))
+	_, _ = fmt.Fprintf(&svg, `<rect width="100%%" height="100%%" fill="#ffffff"/><path fill="#000000" d="%s"/></svg>`, path.String())
+	return []byte(svg.String()), nil
+}
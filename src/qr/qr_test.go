@@ -0,0 +1,64 @@
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/skip2/go-qrcode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLevel(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Act
+		level, err := ParseLevel("h")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, qrcode.Highest, level)
+	})
+
+	t.Run("Default Level", func(t *testing.T) {
+		// Act
+		level, err := ParseLevel("")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, qrcode.Medium, level)
+	})
+
+	t.Run("Unknown Level", func(t *testing.T) {
+		// Act
+		_, err := ParseLevel("X")
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestPNG(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Act
+		data, err := PNG("https://example.com/join/AB3X7K", 128, qrcode.Medium)
+
+		// Assert
+		assert.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 128, img.Bounds().Dx())
+	})
+}
+
+func TestSVG(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Act
+		data, err := SVG("https://example.com/join/AB3X7K", 300, qrcode.Low)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data, []byte("<svg ")))
+		assert.Contains(t, string(data), `width="300"`)
+		assert.Contains(t, string(data), "h1v1h-1z")
+	})
+}
@@ -0,0 +1,142 @@
+package reactions
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// retention is how many seconds of counters are kept per presentation before
+// they are discarded.
+const retention = 60
+
+var (
+	ErrUnknownEmoji = errors.New("unknown emoji")
+	ErrRateLimited  = errors.New("too many reactions")
+)
+
+// Emojis are the reactions the audience is allowed to send.
+var Emojis = []string{"👍", "❤️", "😂", "👏"}
+
+type clientKey struct {
+	presentationID uuid.UUID
+	clientID       string
+}
+
+type window struct {
+	second int64
+	count  int
+}
+
+// Hub aggregates reactions into per-second counters in memory and rate-limits
+// every client to a fixed number of reactions per second.
+type Hub struct {
+	mu         sync.Mutex
+	limit      int
+	counts     map[uuid.UUID]map[int64]map[string]int
+	clients    map[clientKey]*window
+	lastPruned int64
+	now        func() time.Time
+}
+
+func NewHub(limit int) *Hub {
+	return &Hub{
+		limit:   limit,
+		counts:  make(map[uuid.UUID]map[int64]map[string]int),
+		clients: make(map[clientKey]*window),
+		now:     time.Now,
+	}
+}
+
+// Now returns the unix second the hub is currently counting reactions for.
+func (h *Hub) Now() int64 {
+	return h.now().Unix()
+}
+
+// Record counts a single reaction sent by a client for a presentation.
+func (h *Hub) Record(presentationID uuid.UUID, clientID string, emoji string) error {
+	emoji, ok := canonicalEmoji(emoji)
+	if !ok {
+		return ErrUnknownEmoji
+	}
+
+	second := h.Now()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if second != h.lastPruned {
+		h.prune(second)
+	}
+
+	key := clientKey{presentationID: presentationID, clientID: clientID}
+	clientWindow, found := h.clients[key]
+	if !found || clientWindow.second != second {
+		clientWindow = &window{second: second}
+		h.clients[key] = clientWindow
+	}
+	if clientWindow.count >= h.limit {
+		return ErrRateLimited
+	}
+	clientWindow.count++
+
+	seconds, found := h.counts[presentationID]
+	if !found {
+		seconds = make(map[int64]map[string]int)
+		h.counts[presentationID] = seconds
+	}
+	counts, found := seconds[second]
+	if !found {
+		counts = make(map[string]int)
+		seconds[second] = counts
+	}
+	counts[emoji]++
+
+	return nil
+}
+
+// Counts returns a copy of the counters recorded for a presentation during
+// the given unix second.
+func (h *Hub) Counts(presentationID uuid.UUID, second int64) map[string]int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	counts := make(map[string]int)
+	for emoji, count := range h.counts[presentationID][second] {
+		counts[emoji] = count
+	}
+	return counts
+}
+
+func (h *Hub) prune(second int64) {
+	for presentationID, seconds := range h.counts {
+		for recorded := range seconds {
+			if recorded <= second-retention {
+				delete(seconds, recorded)
+			}
+		}
+		if len(seconds) == 0 {
+			delete(h.counts, presentationID)
+		}
+	}
+	for key, clientWindow := range h.clients {
+		if clientWindow.second < second {
+			delete(h.clients, key)
+		}
+	}
+	h.lastPruned = second
+}
+
+// canonicalEmoji maps an emoji to its entry in Emojis, ignoring the variation
+// selector some keyboards leave out.
+func canonicalEmoji(emoji string) (string, bool) {
+	for _, allowed := range Emojis {
+		if strings.TrimSuffix(emoji, "️") == strings.TrimSuffix(allowed, "️") {
+			return allowed, true
+		}
+	}
+	return "", false
+}
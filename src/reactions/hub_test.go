@@ -0,0 +1,61 @@
+package reactions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHubRecord(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		hub := NewHub(5)
+		hub.now = func() time.Time { return time.Unix(100, 0) }
+		presentationID := uuid.New()
+
+		// Act
+		errFirst := hub.Record(presentationID, "client-1", "👍")
+		errSecond := hub.Record(presentationID, "client-2", "❤")
+
+		// Assert
+		assert.NoError(t, errFirst)
+		assert.NoError(t, errSecond)
+		assert.Equal(t, map[string]int{"👍": 1, "❤️": 1}, hub.Counts(presentationID, 100))
+		assert.Empty(t, hub.Counts(presentationID, 101))
+	})
+
+	t.Run("Unknown Emoji", func(t *testing.T) {
+		// Arrange
+		hub := NewHub(5)
+
+		// Act
+		err := hub.Record(uuid.New(), "client-1", "🐊")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrUnknownEmoji)
+	})
+
+	t.Run("Rate Limited Per Client", func(t *testing.T) {
+		// Arrange
+		now := time.Unix(100, 0)
+		hub := NewHub(2)
+		hub.now = func() time.Time { return now }
+		presentationID := uuid.New()
+
+		// Act
+		_ = hub.Record(presentationID, "client-1", "👏")
+		_ = hub.Record(presentationID, "client-1", "👏")
+		errLimited := hub.Record(presentationID, "client-1", "👏")
+		errOtherClient := hub.Record(presentationID, "client-2", "👏")
+		now = now.Add(time.Second)
+		errNextSecond := hub.Record(presentationID, "client-1", "👏")
+
+		// Assert
+		assert.ErrorIs(t, errLimited, ErrRateLimited)
+		assert.NoError(t, errOtherClient)
+		assert.NoError(t, errNextSecond)
+		assert.Equal(t, map[string]int{"👏": 3}, hub.Counts(presentationID, 100))
+	})
+}
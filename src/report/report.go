@@ -0,0 +1,73 @@
+// Package report renders post-session reports of a presentation as HTML or
+// Markdown documents.
+package report
+
+import (
+	"embed"
+	htmltemplate "html/template"
+	"io"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var functions = map[string]interface{}{
+	"timestamp": func(t time.Time) string {
+		return t.UTC().Format("2006-01-02 15:04:05 UTC")
+	},
+	"percent": func(value float64) string {
+		return strconv.FormatFloat(value, 'f', 1, 64) + "%"
+	},
+	"inc": func(i int) int {
+		return i + 1
+	},
+	"cell": func(value string) string {
+		return strings.NewReplacer("|", `\|`, "\n", " ").Replace(value)
+	},
+}
+
+var (
+	htmlTemplate     = htmltemplate.Must(htmltemplate.New("report.html.tmpl").Funcs(functions).ParseFS(templates, "templates/report.html.tmpl"))
+	markdownTemplate = texttemplate.Must(texttemplate.New("report.md.tmpl").Funcs(functions).ParseFS(templates, "templates/report.md.tmpl"))
+)
+
+type Report struct {
+	PresentationID uuid.UUID
+	GeneratedAt    time.Time
+	// Participants is the number of distinct clients that voted on at least
+	// one poll of the presentation.
+	Participants int
+	Polls        []Poll
+}
+
+type Poll struct {
+	Index    int
+	Question string
+	ShownAt  []time.Time
+	Votes    int
+	// Participation is the share of the presentation's participants that
+	// voted on this poll, in percent.
+	Participation float64
+	Options       []Option
+}
+
+type Option struct {
+	Key        string
+	Value      string
+	Count      int
+	Percentage float64
+}
+
+func HTML(w io.Writer, report Report) error {
+	return htmlTemplate.Execute(w, report)
+}
+
+func Markdown(w io.Writer, report Report) error {
+	return markdownTemplate.Execute(w, report)
+}
@@ -0,0 +1,69 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var sample = Report{
+	PresentationID: uuid.MustParse("425ba663-ecaf-4902-84c2-2f7d1aa3d1d7"),
+	GeneratedAt:    time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	Participants:   4,
+	Polls: []Poll{
+		{
+			Index:         0,
+			Question:      "Cats | dogs <or> crocodiles?",
+			ShownAt:       []time.Time{time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC)},
+			Votes:         3,
+			Participation: 75,
+			Options: []Option{
+				{Key: "A", Value: "Dog", Count: 2, Percentage: 200.0 / 3},
+				{Key: "B", Value: "Cat", Count: 1, Percentage: 100.0 / 3},
+			},
+		},
+		{
+			Index:    1,
+			Question: "Which country?",
+		},
+	},
+}
+
+func TestHTML(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		var buffer bytes.Buffer
+
+		// Act
+		err := HTML(&buffer, sample)
+
+		// Assert
+		assert.NoError(t, err)
+		html := buffer.String()
+		assert.Contains(t, html, "<h2>1. Cats | dogs &lt;or&gt; crocodiles?</h2>")
+		assert.Contains(t, html, "participation 75.0%")
+		assert.Contains(t, html, "shown 2024-05-01 11:00:00 UTC")
+		assert.Contains(t, html, `<td class="number">66.7%</td>`)
+		assert.Contains(t, html, "never shown")
+	})
+}
+
+func TestMarkdown(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		var buffer bytes.Buffer
+
+		// Act
+		err := Markdown(&buffer, sample)
+
+		// Assert
+		assert.NoError(t, err)
+		markdown := buffer.String()
+		assert.Contains(t, markdown, "## 1. Cats | dogs <or> crocodiles?")
+		assert.Contains(t, markdown, "| A | Dog | 2 | 66.7% |")
+		assert.Contains(t, markdown, "## 2. Which country?")
+	})
+}
@@ -1,27 +1,99 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"reflect"
+	"sync"
 
 	"github.com/google/uuid"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"interactive-presentation/src/config"
+	"interactive-presentation/src/models"
 )
 
+var (
+	poolMutex     sync.Mutex
+	pool          *sql.DB
+	configuration *config.Config
+)
+
+// Configure sets the database URL and pool sizes the connection pool is
+// opened with. Without it they are loaded from the environment on first use.
+func Configure(c *config.Config) {
+	poolMutex.Lock()
+	defer poolMutex.Unlock()
+
+	configuration = c
+}
+
+// connectToDatabase returns the connection pool shared by all storage
+// functions, opening it on first use.
 func connectToDatabase() (*sql.DB, error) {
-	configuration, err := config.New()
-	if err != nil {
-		return nil, fmt.Errorf("error initializing config: %v", err)
+	poolMutex.Lock()
+	defer poolMutex.Unlock()
+
+	if pool != nil {
+		return pool, nil
+	}
+
+	if configuration == nil {
+		c, err := config.New()
+		if err != nil {
+			return nil, fmt.Errorf("error initializing config: %v", err)
+		}
+		configuration = c
 	}
 
 	db, err := sql.Open("postgres", configuration.DatabaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("error opening db connection: %v", err)
 	}
-	return db, nil
+	db.SetMaxOpenConns(configuration.DBMaxOpenConns)
+	db.SetMaxIdleConns(configuration.DBMaxIdleConns)
+	db.SetConnMaxLifetime(configuration.DBConnMaxLifetime)
+	db.SetConnMaxIdleTime(configuration.DBConnMaxIdleTime)
+	pool = db
+	return pool, nil
+}
+
+// SetDB replaces the shared connection pool, e.g. with a stub in tests.
+func SetDB(db *sql.DB) {
+	poolMutex.Lock()
+	defer poolMutex.Unlock()
+
+	pool = db
+}
+
+// DB returns the shared connection pool, e.g. to report its statistics.
+func DB() (*sql.DB, error) {
+	return connectToDatabase()
+}
+
+// Close closes the shared connection pool. The next storage call opens a new
+// one.
+func Close() error {
+	poolMutex.Lock()
+	defer poolMutex.Unlock()
+
+	if pool == nil {
+		return nil
+	}
+	err := pool.Close()
+	pool = nil
+	return err
+}
+
+// Ping checks that the database can be reached.
+func Ping(ctx context.Context) error {
+	db, err := connectToDatabase()
+	if err != nil {
+		return fmt.Errorf("error connecting to database: %v", err)
+	}
+	return db.PingContext(ctx)
 }
 
 func scanArgs(elem reflect.Value) []interface{} {
@@ -32,16 +104,11 @@ func scanArgs(elem reflect.Value) []interface{} {
 	return args
 }
 
-func InsertIntoDatabase(table string, args ...interface{}) error {
+func InsertIntoDatabase(ctx context.Context, table string, args ...interface{}) error {
 	db, err := connectToDatabase()
 	if err != nil {
 		return fmt.Errorf("error connecting to database: %v", err)
 	}
-	defer func() {
-		if err = db.Close(); err != nil {
-			log.Printf("warning: error closing database connection: %v", err)
-		}
-	}()
 
 	if err != nil {
 		return fmt.Errorf("error beginning database transaction: %v", err)
@@ -50,13 +117,23 @@ func InsertIntoDatabase(table string, args ...interface{}) error {
 	var insertStatement string
 	switch table {
 	case "presentation":
-		insertStatement = fmt.Sprintf("INSERT INTO %s (presentation_id, current_poll_index) VALUES ($1, $2)", table)
+		insertStatement = fmt.Sprintf("INSERT INTO %s (presentation_id, current_poll_index, version) VALUES ($1, $2, $3)", table)
 	case "poll":
-		insertStatement = fmt.Sprintf("INSERT INTO %s (poll_id, question, presentation_id, index) VALUES ($1, $2, $3, $4)", table)
+		insertStatement = fmt.Sprintf("INSERT INTO %s (poll_id, question, presentation_id, index, closed) VALUES ($1, $2, $3, $4, $5)", table)
 	case "option":
 		insertStatement = fmt.Sprintf("INSERT INTO %s (key, value, poll_id, index) VALUES ($1, $2, $3, $4)", table)
 	case "vote":
 		insertStatement = fmt.Sprintf("INSERT INTO %s (key, client_id, poll_id) VALUES ($1, $2, $3)", table)
+	case "submission":
+		insertStatement = fmt.Sprintf("INSERT INTO %s (submission_id, poll_id, client_id, text, status, created_at) VALUES ($1, $2, $3, $4, $5, $6)", table)
+	case "blocklist":
+		insertStatement = fmt.Sprintf("INSERT INTO %s (presentation_id, word) VALUES ($1, $2)", table)
+	case "presenter_token":
+		insertStatement = fmt.Sprintf("INSERT INTO %s (presentation_id, token_hash) VALUES ($1, $2)", table)
+	case "join_code":
+		insertStatement = fmt.Sprintf("INSERT INTO %s (code, presentation_id, expires_at) VALUES ($1, $2, $3)", table)
+	case "poll_shown":
+		insertStatement = fmt.Sprintf("INSERT INTO %s (presentation_id, poll_index, shown_at) VALUES ($1, $2, $3)", table)
 	default:
 		return fmt.Errorf("unknown table: %s", table)
 	}
@@ -73,24 +150,21 @@ func InsertIntoDatabase(table string, args ...interface{}) error {
 		}
 	}
 
-	_, err = db.Exec(insertStatement, argsList...)
+	ctx, span := startSpan(ctx, "INSERT", table)
+	result, err := db.ExecContext(ctx, insertStatement, argsList...)
+	endSpan(span, rowsAffected(result), err)
 	if err != nil {
-		return fmt.Errorf("error executing insert statement for table %s: %v", table, err)
+		return fmt.Errorf("error executing insert statement for table %s: %w", table, err)
 	}
 
 	return nil
 }
 
-func SelectFromTable[T any](table string, conditionID interface{}, dest *[]T) error {
+func SelectFromTable[T any](ctx context.Context, table string, conditionID interface{}, dest *[]T) error {
 	db, err := connectToDatabase()
 	if err != nil {
 		return fmt.Errorf("error connecting to database: %v", err)
 	}
-	defer func() {
-		if err = db.Close(); err != nil {
-			log.Printf("warning: error closing database connection: %v", err)
-		}
-	}()
 
 	var selectStatement string
 	switch table {
@@ -101,19 +175,41 @@ func SelectFromTable[T any](table string, conditionID interface{}, dest *[]T) er
 	case "option":
 		selectStatement = fmt.Sprintf("SELECT * FROM %s WHERE poll_id=$1", table)
 	case "vote":
-		selectStatement = fmt.Sprintf("SELECT * FROM %s WHERE poll_id=$1", table)
+		selectStatement = fmt.Sprintf("SELECT key, client_id, poll_id FROM %s WHERE poll_id=$1 ORDER BY vote_id", table)
+	case "submission":
+		selectStatement = fmt.Sprintf("SELECT * FROM %s WHERE poll_id=$1 ORDER BY created_at", table)
+	case "blocklist":
+		selectStatement = fmt.Sprintf("SELECT * FROM %s WHERE presentation_id=$1", table)
+	case "presenter_token":
+		selectStatement = fmt.Sprintf("SELECT * FROM %s WHERE presentation_id=$1", table)
+	case "join_code":
+		selectStatement = fmt.Sprintf("SELECT * FROM %s WHERE presentation_id=$1", table)
+	case "poll_shown":
+		selectStatement = fmt.Sprintf("SELECT * FROM %s WHERE presentation_id=$1 ORDER BY shown_at", table)
 	default:
 		return fmt.Errorf("unknown table: %s", table)
 	}
 
-	rows, err := db.Query(selectStatement, conditionID)
+	return queryRows(ctx, db, table, selectStatement, conditionID, dest)
+}
+
+func queryRows[T any](ctx context.Context, db *sql.DB, table string, selectStatement string, conditionID interface{}, dest *[]T) (err error) {
+	ctx, span := startSpan(ctx, "SELECT", table)
+	var count int64
+	defer func() {
+		endSpan(span, count, err)
+	}()
+
+	rows, err := db.QueryContext(ctx, selectStatement, conditionID)
 	if err != nil {
 		return fmt.Errorf("error running select query for table %s: %v", table, err)
 	}
 	defer func(rows *sql.Rows) {
-		err = rows.Close()
-		if err != nil {
-			log.Printf("warning: error closing rows: %v", err)
+		if closeErr := rows.Close(); closeErr != nil {
+			slog.Warn("error closing rows", "error", closeErr)
+			if err == nil {
+				err = closeErr
+			}
 		}
 	}(rows)
 
@@ -124,6 +220,7 @@ func SelectFromTable[T any](table string, conditionID interface{}, dest *[]T) er
 			return fmt.Errorf("error scanning row from select query for table %s: %v", table, err)
 		}
 		*dest = append(*dest, elem.Interface().(T))
+		count++
 	}
 
 	if err = rows.Err(); err != nil {
@@ -133,22 +230,365 @@ func SelectFromTable[T any](table string, conditionID interface{}, dest *[]T) er
 	return nil
 }
 
-func UpdatePresentation(presentationID uuid.UUID, currentPollIndex int) error {
+func UpdatePresentation(ctx context.Context, presentationID uuid.UUID, currentPollIndex int) error {
 	db, err := connectToDatabase()
 	if err != nil {
 		return err
 	}
+
+	query := fmt.Sprintf("UPDATE %s SET current_poll_index = $1, version = version + 1 WHERE presentation_id = $2", "presentation")
+	ctx, span := startSpan(ctx, "UPDATE", "presentation")
+	result, err := db.ExecContext(ctx, query, currentPollIndex, presentationID)
+	endSpan(span, rowsAffected(result), err)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// LatestVoteID returns the ID of the latest vote for the poll at index of a
+// presentation, or 0 before the first vote.
+func LatestVoteID(ctx context.Context, presentationID uuid.UUID, index int) (int64, error) {
+	db, err := connectToDatabase()
+	if err != nil {
+		return 0, fmt.Errorf("error connecting to database: %v", err)
+	}
+
+	query := `SELECT COALESCE(MAX(vote_id), 0) FROM vote
+		WHERE poll_id = (SELECT poll_id FROM poll WHERE presentation_id = $1 AND index = $2)`
+	ctx, span := startSpan(ctx, "SELECT", "vote")
+	var voteID int64
+	err = db.QueryRowContext(ctx, query, presentationID, index).Scan(&voteID)
+	endSpan(span, 1, err)
+	if err != nil {
+		return 0, fmt.Errorf("error selecting latest vote: %v", err)
+	}
+
+	return voteID, nil
+}
+
+func DeleteFromTable(ctx context.Context, table string, conditionID interface{}) error {
+	db, err := connectToDatabase()
+	if err != nil {
+		return fmt.Errorf("error connecting to database: %v", err)
+	}
+
+	var deleteStatement string
+	switch table {
+	case "blocklist":
+		deleteStatement = fmt.Sprintf("DELETE FROM %s WHERE presentation_id=$1", table)
+	case "join_code":
+		deleteStatement = fmt.Sprintf("DELETE FROM %s WHERE presentation_id=$1", table)
+	default:
+		return fmt.Errorf("unknown table: %s", table)
+	}
+
+	ctx, span := startSpan(ctx, "DELETE", table)
+	result, err := db.ExecContext(ctx, deleteStatement, conditionID)
+	endSpan(span, rowsAffected(result), err)
+	if err != nil {
+		return fmt.Errorf("error executing delete statement for table %s: %v", table, err)
+	}
+
+	return nil
+}
+
+// UpdatePollClosed opens or closes the poll at index and bumps the version of
+// its presentation. It returns the number of polls updated.
+func UpdatePollClosed(ctx context.Context, presentationID uuid.UUID, index int, closed bool) (int64, error) {
+	db, err := connectToDatabase()
+	if err != nil {
+		return 0, err
+	}
+
+	query := `WITH updated AS (UPDATE poll SET closed = $1 WHERE presentation_id = $2 AND index = $3 RETURNING presentation_id)
+		UPDATE presentation SET version = version + 1 WHERE presentation_id IN (SELECT presentation_id FROM updated)`
+	ctx, span := startSpan(ctx, "UPDATE", "poll")
+	result, err := db.ExecContext(ctx, query, closed, presentationID, index)
+	endSpan(span, rowsAffected(result), err)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// UpdateSubmissionStatus sets the status of a submission to a poll of the
+// presentation. It returns the number of submissions updated, which is 0 for
+// submissions to polls of other presentations.
+func UpdateSubmissionStatus(ctx context.Context, presentationID uuid.UUID, submissionID uuid.UUID, status string) (int64, error) {
+	db, err := connectToDatabase()
+	if err != nil {
+		return 0, err
+	}
+
+	query := `UPDATE submission SET status = $1
+		WHERE submission_id = $2 AND poll_id IN (SELECT poll_id FROM poll WHERE presentation_id = $3)`
+	ctx, span := startSpan(ctx, "UPDATE", "submission")
+	result, err := db.ExecContext(ctx, query, status, submissionID, presentationID)
+	endSpan(span, rowsAffected(result), err)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func SelectJoinCode[T any](ctx context.Context, code string, dest *[]T) error {
+	db, err := connectToDatabase()
+	if err != nil {
+		return fmt.Errorf("error connecting to database: %v", err)
+	}
+
+	selectStatement := fmt.Sprintf("SELECT * FROM %s WHERE code=$1 AND expires_at > now()", "join_code")
+	return queryRows(ctx, db, "join_code", selectStatement, code, dest)
+}
+
+// SelectCurrentJoinCode selects the newest join code of a presentation that
+// has not expired. While a code is being replaced both are valid, and the
+// new one is the one to hand out.
+func SelectCurrentJoinCode[T any](ctx context.Context, presentationID uuid.UUID, dest *[]T) error {
+	db, err := connectToDatabase()
+	if err != nil {
+		return fmt.Errorf("error connecting to database: %v", err)
+	}
+
+	selectStatement := fmt.Sprintf("SELECT * FROM %s WHERE presentation_id=$1 AND expires_at > now() ORDER BY expires_at DESC LIMIT 1", "join_code")
+	return queryRows(ctx, db, "join_code", selectStatement, presentationID, dest)
+}
+
+func DeleteExpiredJoinCodes(ctx context.Context) error {
+	db, err := connectToDatabase()
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE expires_at <= now()", "join_code")
+	ctx, span := startSpan(ctx, "DELETE", "join_code")
+	result, err := db.ExecContext(ctx, query)
+	endSpan(span, rowsAffected(result), err)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteOtherJoinCodes removes every join code of a presentation but code,
+// e.g. once a new code replaced them.
+func DeleteOtherJoinCodes(ctx context.Context, presentationID uuid.UUID, code string) error {
+	db, err := connectToDatabase()
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE presentation_id = $1 AND code <> $2", "join_code")
+	ctx, span := startSpan(ctx, "DELETE", "join_code")
+	result, err := db.ExecContext(ctx, query, presentationID, code)
+	endSpan(span, rowsAffected(result), err)
+	return err
+}
+
+// ReplaceBlocklist replaces the blocked words of a presentation in a single
+// transaction, so a failure leaves the previous words in place.
+func ReplaceBlocklist(ctx context.Context, presentationID uuid.UUID, words []string) (err error) {
+	db, err := connectToDatabase()
+	if err != nil {
+		return fmt.Errorf("error connecting to database: %v", err)
+	}
+
+	ctx, span := startSpan(ctx, "UPDATE", "blocklist")
 	defer func() {
-		if err = db.Close(); err != nil {
-			log.Printf("warning: error closing database connection: %v", err)
+		endSpan(span, int64(len(words)), err)
+	}()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error beginning database transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM blocklist WHERE presentation_id = $1", presentationID); err != nil {
+		return fmt.Errorf("error executing delete statement for table blocklist: %v", err)
+	}
+	for _, word := range words {
+		if _, err = tx.ExecContext(ctx, "INSERT INTO blocklist (presentation_id, word) VALUES ($1, $2)", presentationID, word); err != nil {
+			return fmt.Errorf("error executing insert statement for table blocklist: %v", err)
 		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing blocklist: %v", err)
+	}
+	return nil
+}
+
+// IsUniqueViolation reports whether err was caused by an insert conflicting
+// with a primary key or unique constraint.
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// ForEachVote calls handle for every vote of a presentation joined with its
+// poll and option, ordered by poll index and then in the order the votes were
+// recorded. Rows are handed over one at a time while they are read so the
+// result set is never held in memory.
+func ForEachVote(ctx context.Context, presentationID uuid.UUID, handle func(models.VoteExport) error) error {
+	return forEachVote(ctx, "WHERE poll.presentation_id = $1 ORDER BY poll.index, vote.vote_id", handle, presentationID)
+}
+
+// ForEachVoteAfter calls handle for every vote of a presentation recorded
+// after the vote with the given ID, in the order they were recorded.
+func ForEachVoteAfter(ctx context.Context, presentationID uuid.UUID, voteID int64, handle func(models.VoteExport) error) error {
+	return forEachVote(ctx, "WHERE poll.presentation_id = $1 AND vote.vote_id > $2 ORDER BY vote.vote_id", handle, presentationID, voteID)
+}
+
+func forEachVote(ctx context.Context, condition string, handle func(models.VoteExport) error, args ...interface{}) (err error) {
+	db, err := connectToDatabase()
+	if err != nil {
+		return fmt.Errorf("error connecting to database: %v", err)
+	}
+
+	query := `SELECT poll.index, poll.poll_id, poll.question, vote.key, COALESCE(option.value, ''), vote.client_id, vote.vote_id, vote.created_at
+		FROM poll
+		JOIN vote ON vote.poll_id = poll.poll_id
+		LEFT JOIN option ON option.poll_id = vote.poll_id AND option.key = vote.key
+		` + condition
+	ctx, span := startSpan(ctx, "SELECT", "vote")
+	var count int64
+	defer func() {
+		endSpan(span, count, err)
 	}()
 
-	query := fmt.Sprintf("UPDATE %s SET current_poll_index = $1 WHERE presentation_id = $2", "presentation")
-	_, err = db.Exec(query, currentPollIndex, presentationID)
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return err
+		return fmt.Errorf("error running export query: %v", err)
+	}
+	defer func(rows *sql.Rows) {
+		if closeErr := rows.Close(); closeErr != nil {
+			slog.Warn("error closing rows", "error", closeErr)
+			if err == nil {
+				err = closeErr
+			}
+		}
+	}(rows)
+
+	for rows.Next() {
+		var vote models.VoteExport
+		if err = rows.Scan(&vote.PollIndex, &vote.PollID, &vote.Question, &vote.Key, &vote.Value, &vote.ClientID, &vote.VoteID, &vote.CreatedAt); err != nil {
+			return fmt.Errorf("error scanning row from export query: %v", err)
+		}
+		if err = handle(vote); err != nil {
+			return err
+		}
+		count++
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating over rows: %v", err)
 	}
 
 	return nil
 }
+
+func ListPresentations(ctx context.Context) (presentations []models.PresentationSummary, err error) {
+	db, err := connectToDatabase()
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to database: %v", err)
+	}
+
+	query := `SELECT presentation.presentation_id, presentation.current_poll_index,
+			(SELECT COUNT(*) FROM poll WHERE poll.presentation_id = presentation.presentation_id),
+			COALESCE((SELECT code FROM join_code WHERE join_code.presentation_id = presentation.presentation_id AND expires_at > now() ORDER BY expires_at DESC LIMIT 1), '')
+		FROM presentation
+		ORDER BY presentation.presentation_id`
+	ctx, span := startSpan(ctx, "SELECT", "presentation")
+	defer func() {
+		endSpan(span, int64(len(presentations)), err)
+	}()
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error running list query: %v", err)
+	}
+	defer func(rows *sql.Rows) {
+		if closeErr := rows.Close(); closeErr != nil {
+			slog.Warn("error closing rows", "error", closeErr)
+			if err == nil {
+				err = closeErr
+			}
+		}
+	}(rows)
+
+	for rows.Next() {
+		var presentation models.PresentationSummary
+		if err = rows.Scan(&presentation.PresentationID, &presentation.CurrentPollIndex, &presentation.Polls, &presentation.JoinCode); err != nil {
+			return nil, fmt.Errorf("error scanning row from list query: %v", err)
+		}
+		presentations = append(presentations, presentation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %v", err)
+	}
+
+	return presentations, nil
+}
+
+// DeletePresentation removes a presentation with its polls, options, votes,
+// submissions and every other row referring to it in a single transaction.
+// It reports whether the presentation existed.
+func DeletePresentation(ctx context.Context, presentationID uuid.UUID) (deleted bool, err error) {
+	db, err := connectToDatabase()
+	if err != nil {
+		return false, fmt.Errorf("error connecting to database: %v", err)
+	}
+
+	ctx, span := startSpan(ctx, "DELETE", "presentation")
+	defer func() {
+		endSpan(span, 0, err)
+	}()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("error beginning database transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	statements := []string{
+		"DELETE FROM vote WHERE poll_id IN (SELECT poll_id FROM poll WHERE presentation_id = $1)",
+		"DELETE FROM option WHERE poll_id IN (SELECT poll_id FROM poll WHERE presentation_id = $1)",
+		"DELETE FROM submission WHERE poll_id IN (SELECT poll_id FROM poll WHERE presentation_id = $1)",
+		"DELETE FROM poll WHERE presentation_id = $1",
+		"DELETE FROM blocklist WHERE presentation_id = $1",
+		"DELETE FROM presenter_token WHERE presentation_id = $1",
+		"DELETE FROM join_code WHERE presentation_id = $1",
+		"DELETE FROM poll_shown WHERE presentation_id = $1",
+	}
+	for _, statement := range statements {
+		if _, err = tx.ExecContext(ctx, statement, presentationID); err != nil {
+			return false, fmt.Errorf("error executing delete statement: %v", err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM presentation WHERE presentation_id = $1", presentationID)
+	if err != nil {
+		return false, fmt.Errorf("error executing delete statement for table presentation: %v", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("error committing delete: %v", err)
+	}
+
+	return affected > 0, nil
+}
@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"interactive-presentation/src/models"
+)
+
+func mockDB(t *testing.T) sqlmock.Sqlmock {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetDB(db)
+	t.Cleanup(func() {
+		_ = Close()
+	})
+	return mock
+}
+
+func TestSelectFromTable(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		presentationID := uuid.New()
+		mock.ExpectQuery("SELECT \\* FROM presentation").WithArgs(presentationID).
+			WillReturnRows(sqlmock.NewRows([]string{"presentation_id", "current_poll_index", "version"}).AddRow(presentationID, 2, 5))
+
+		// Act
+		var presentations []models.PresentationDB
+		err := SelectFromTable(context.Background(), "presentation", presentationID, &presentations)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []models.PresentationDB{{PresentationID: presentationID, CurrentPollIndex: 2, Version: 5}}, presentations)
+	})
+
+	t.Run("Scan Error", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM presentation").
+			WillReturnRows(sqlmock.NewRows([]string{"presentation_id", "current_poll_index", "version"}).AddRow("not-a-uuid", 0, 1))
+
+		// Act
+		var presentations []models.PresentationDB
+		err := SelectFromTable(context.Background(), "presentation", uuid.New(), &presentations)
+
+		// Assert
+		assert.ErrorContains(t, err, "error scanning row")
+	})
+
+	t.Run("Iteration Error", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT \\* FROM presentation").
+			WillReturnRows(sqlmock.NewRows([]string{"presentation_id", "current_poll_index", "version"}).
+				AddRow(uuid.New(), 0, 1).RowError(0, errors.New("connection reset")))
+
+		// Act
+		var presentations []models.PresentationDB
+		err := SelectFromTable(context.Background(), "presentation", uuid.New(), &presentations)
+
+		// Assert
+		assert.ErrorContains(t, err, "connection reset")
+	})
+}
+
+func TestForEachVote(t *testing.T) {
+	columns := []string{"index", "poll_id", "question", "key", "value", "client_id", "vote_id", "created_at"}
+	createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		pollID := uuid.New()
+		mock.ExpectQuery("SELECT poll.index.* ORDER BY poll.index, vote.vote_id").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(0, pollID, "Pets?", "a", "Cats", "client-1", 1, createdAt).AddRow(0, pollID, "Pets?", "b", "Dogs", "client-2", 2, createdAt))
+
+		// Act
+		var votes []models.VoteExport
+		err := ForEachVote(context.Background(), uuid.New(), func(vote models.VoteExport) error {
+			votes = append(votes, vote)
+			return nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []models.VoteExport{
+			{PollIndex: 0, PollID: pollID, Question: "Pets?", Key: "a", Value: "Cats", ClientID: "client-1", VoteID: 1, CreatedAt: createdAt},
+			{PollIndex: 0, PollID: pollID, Question: "Pets?", Key: "b", Value: "Dogs", ClientID: "client-2", VoteID: 2, CreatedAt: createdAt},
+		}, votes)
+	})
+
+	t.Run("Callback Error", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT poll.index").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(0, uuid.New(), "Pets?", "a", "Cats", "client-1", 1, createdAt))
+		handleErr := errors.New("client went away")
+
+		// Act
+		err := ForEachVote(context.Background(), uuid.New(), func(models.VoteExport) error {
+			return handleErr
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, handleErr)
+	})
+}
+
+func TestForEachVoteAfter(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		presentationID := uuid.New()
+		mock.ExpectQuery("SELECT poll.index.* vote.vote_id > \\$2 ORDER BY vote.vote_id").WithArgs(presentationID, int64(7)).
+			WillReturnRows(sqlmock.NewRows([]string{"index", "poll_id", "question", "key", "value", "client_id", "vote_id", "created_at"}).
+				AddRow(1, uuid.New(), "Pets?", "a", "Cats", "client-1", 8, time.Now()))
+
+		// Act
+		var voteIDs []int64
+		err := ForEachVoteAfter(context.Background(), presentationID, 7, func(vote models.VoteExport) error {
+			voteIDs = append(voteIDs, vote.VoteID)
+			return nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{8}, voteIDs)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReplaceBlocklist(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		presentationID := uuid.New()
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM blocklist").WithArgs(presentationID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO blocklist").WithArgs(presentationID, "spoiler").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO blocklist").WithArgs(presentationID, "acme corp").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// Act
+		err := ReplaceBlocklist(context.Background(), presentationID, []string{"spoiler", "acme corp"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Insert Error", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		presentationID := uuid.New()
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM blocklist").WithArgs(presentationID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO blocklist").WithArgs(presentationID, "spoiler").WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		// Act
+		err := ReplaceBlocklist(context.Background(), presentationID, []string{"spoiler", "acme corp"})
+
+		// Assert
+		assert.ErrorContains(t, err, "connection reset")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListPresentations(t *testing.T) {
+	t.Run("Scan Error", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectQuery("SELECT presentation.presentation_id").
+			WillReturnRows(sqlmock.NewRows([]string{"presentation_id", "current_poll_index", "polls", "join_code"}).AddRow("not-a-uuid", 0, 1, ""))
+
+		// Act
+		presentations, err := ListPresentations(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, presentations)
+	})
+}
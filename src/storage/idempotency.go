@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"interactive-presentation/src/models"
+)
+
+// ReserveIdempotencyKey records that the request with the given key, path and
+// payload hash is being processed and reports true. When the key was already
+// used for the path it returns the recorded request and reports false
+// instead. Keys older than ttl are forgotten first, as are reservations older
+// than lease whose request never completed, e.g. because the process died.
+func ReserveIdempotencyKey(ctx context.Context, key string, path string, requestHash string, ttl time.Duration, lease time.Duration) (models.IdempotencyKeyDB, bool, error) {
+	db, err := connectToDatabase()
+	if err != nil {
+		return models.IdempotencyKeyDB{}, false, fmt.Errorf("error connecting to database: %v", err)
+	}
+
+	deleteCtx, span := startSpan(ctx, "DELETE", "idempotency_key")
+	now := time.Now().UTC()
+	result, err := db.ExecContext(deleteCtx, "DELETE FROM idempotency_key WHERE created_at <= $1 OR (status = 0 AND created_at <= $2)",
+		now.Add(-ttl), now.Add(-lease))
+	endSpan(span, rowsAffected(result), err)
+	if err != nil {
+		return models.IdempotencyKeyDB{}, false, fmt.Errorf("error deleting expired idempotency keys: %v", err)
+	}
+
+	insertCtx, span := startSpan(ctx, "INSERT", "idempotency_key")
+	result, err = db.ExecContext(insertCtx, "INSERT INTO idempotency_key (key, path, request_hash, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT (key, path) DO NOTHING",
+		key, path, requestHash, now)
+	endSpan(span, rowsAffected(result), err)
+	if err != nil {
+		return models.IdempotencyKeyDB{}, false, fmt.Errorf("error inserting into idempotency_key table: %v", err)
+	}
+	if rowsAffected(result) == 1 {
+		return models.IdempotencyKeyDB{Key: key, Path: path, RequestHash: requestHash}, true, nil
+	}
+
+	var stored models.IdempotencyKeyDB
+	selectCtx, span := startSpan(ctx, "SELECT", "idempotency_key")
+	err = db.QueryRowContext(selectCtx, "SELECT key, path, request_hash, status, content_type, body, created_at FROM idempotency_key WHERE key = $1 AND path = $2", key, path).
+		Scan(&stored.Key, &stored.Path, &stored.RequestHash, &stored.Status, &stored.ContentType, &stored.Body, &stored.CreatedAt)
+	endSpan(span, 1, err)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The first request failed and released the key in the meantime.
+		return ReserveIdempotencyKey(ctx, key, path, requestHash, ttl, lease)
+	}
+	if err != nil {
+		return models.IdempotencyKeyDB{}, false, fmt.Errorf("error selecting from idempotency_key table: %v", err)
+	}
+	return stored, false, nil
+}
+
+// CompleteIdempotencyKey stores the response to a reserved request for replay.
+func CompleteIdempotencyKey(ctx context.Context, key string, path string, status int, contentType string, body []byte) error {
+	db, err := connectToDatabase()
+	if err != nil {
+		return fmt.Errorf("error connecting to database: %v", err)
+	}
+
+	ctx, span := startSpan(ctx, "UPDATE", "idempotency_key")
+	result, err := db.ExecContext(ctx, "UPDATE idempotency_key SET status = $1, content_type = $2, body = $3 WHERE key = $4 AND path = $5",
+		status, contentType, body, key, path)
+	endSpan(span, rowsAffected(result), err)
+	return err
+}
+
+// ReleaseIdempotencyKey forgets a reserved request so it can be retried with
+// the same key.
+func ReleaseIdempotencyKey(ctx context.Context, key string, path string) error {
+	db, err := connectToDatabase()
+	if err != nil {
+		return fmt.Errorf("error connecting to database: %v", err)
+	}
+
+	ctx, span := startSpan(ctx, "DELETE", "idempotency_key")
+	result, err := db.ExecContext(ctx, "DELETE FROM idempotency_key WHERE key = $1 AND path = $2", key, path)
+	endSpan(span, rowsAffected(result), err)
+	return err
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"database/sql/driver"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// ago matches a time about d before now.
+type ago time.Duration
+
+func (d ago) Match(value driver.Value) bool {
+	t, ok := value.(time.Time)
+	return ok && time.Since(t)-time.Duration(d) < time.Minute && time.Since(t) >= time.Duration(d)
+}
+
+func TestReserveIdempotencyKey(t *testing.T) {
+	columns := []string{"key", "path", "request_hash", "status", "content_type", "body", "created_at"}
+
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectExec("DELETE FROM idempotency_key WHERE created_at <= \\$1 OR \\(status = 0 AND created_at <= \\$2\\)").
+			WithArgs(ago(24*time.Hour), ago(30*time.Second)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO idempotency_key").WithArgs("key", "/presentations", "hash", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// Act
+		stored, reserved, err := ReserveIdempotencyKey(context.Background(), "key", "/presentations", "hash", 24*time.Hour, 30*time.Second)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+		assert.Equal(t, "hash", stored.RequestHash)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Key Already Used", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		createdAt := time.Now().UTC()
+		mock.ExpectExec("DELETE FROM idempotency_key").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO idempotency_key").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT key, path, request_hash, status, content_type, body, created_at FROM idempotency_key").
+			WithArgs("key", "/presentations").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("key", "/presentations", "hash", http.StatusCreated, "application/json", []byte(`{}`), createdAt))
+
+		// Act
+		stored, reserved, err := ReserveIdempotencyKey(context.Background(), "key", "/presentations", "hash", 24*time.Hour, 30*time.Second)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, reserved)
+		assert.Equal(t, http.StatusCreated, stored.Status)
+		assert.Equal(t, []byte(`{}`), stored.Body)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// migrations holds the schema changes in the order they are applied. The
+// version of the schema is the number of migrations applied, so new changes
+// are only ever appended.
+var migrations = [][]string{
+	{
+		"CREATE TABLE IF NOT EXISTS presentation (presentation_id uuid PRIMARY KEY, current_poll_index integer);",
+		"CREATE TABLE IF NOT EXISTS poll (poll_id uuid PRIMARY KEY, question VARCHAR(255), presentation_id uuid, index integer);",
+		"CREATE TABLE IF NOT EXISTS option (key VARCHAR(255), value VARCHAR(255), poll_id uuid, index integer);",
+		"CREATE TABLE IF NOT EXISTS vote (key VARCHAR(255), client_id VARCHAR(255), poll_id uuid);",
+		"CREATE TABLE IF NOT EXISTS submission (submission_id uuid PRIMARY KEY, poll_id uuid, client_id VARCHAR(255), text VARCHAR(500), status VARCHAR(16), created_at timestamptz);",
+		"CREATE TABLE IF NOT EXISTS blocklist (presentation_id uuid, word VARCHAR(255));",
+		"CREATE TABLE IF NOT EXISTS presenter_token (presentation_id uuid PRIMARY KEY, token_hash VARCHAR(64));",
+		"CREATE TABLE IF NOT EXISTS join_code (code VARCHAR(16) PRIMARY KEY, presentation_id uuid, expires_at timestamptz);",
+		"CREATE TABLE IF NOT EXISTS poll_shown (presentation_id uuid, poll_index integer, shown_at timestamptz);",
+	},
+	{
+		"ALTER TABLE poll ADD COLUMN IF NOT EXISTS closed boolean NOT NULL DEFAULT false;",
+	},
+	{
+		"CREATE TABLE IF NOT EXISTS idempotency_key (key VARCHAR(255), path VARCHAR(255), request_hash VARCHAR(64), status integer NOT NULL DEFAULT 0, content_type VARCHAR(255) NOT NULL DEFAULT '', body bytea, created_at timestamptz, PRIMARY KEY (key, path));",
+		"CREATE INDEX IF NOT EXISTS idempotency_key_created_at ON idempotency_key (created_at);",
+	},
+	{
+		"ALTER TABLE presentation ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;",
+	},
+	{
+		"ALTER TABLE vote ADD COLUMN IF NOT EXISTS vote_id bigserial PRIMARY KEY;",
+		"ALTER TABLE vote ADD COLUMN IF NOT EXISTS created_at timestamptz NOT NULL DEFAULT now();",
+		"CREATE INDEX IF NOT EXISTS vote_poll_id ON vote (poll_id, vote_id);",
+	},
+}
+
+// migrationLockID is the key of the advisory lock that keeps concurrently
+// starting instances from applying the same migration twice.
+const migrationLockID = 727368
+
+// SchemaVersion is the schema version this build expects.
+func SchemaVersion() int {
+	return len(migrations)
+}
+
+// Migrate applies every migration newer than the version recorded in the
+// schema_migrations table and returns the resulting version.
+func Migrate() (int, error) {
+	db, err := connectToDatabase()
+	if err != nil {
+		return 0, fmt.Errorf("error connecting to database: %v", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("error beginning database transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.Exec("SELECT pg_advisory_xact_lock($1)", migrationLockID); err != nil {
+		return 0, fmt.Errorf("error locking schema_migrations table: %v", err)
+	}
+
+	// The table is only created under the lock, as instances starting at the
+	// same time would otherwise race on creating it.
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS schema_migrations (version integer PRIMARY KEY, applied_at timestamptz DEFAULT now());")
+	if err != nil {
+		return 0, fmt.Errorf("error creating schema_migrations table: %v", err)
+	}
+
+	var version int
+	if err = tx.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version); err != nil {
+		return 0, fmt.Errorf("error reading schema version: %v", err)
+	}
+
+	for ; version < len(migrations); version++ {
+		for _, statement := range migrations[version] {
+			if _, err = tx.Exec(statement); err != nil {
+				return 0, fmt.Errorf("error applying migration %d: %v", version+1, err)
+			}
+		}
+		if _, err = tx.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", version+1); err != nil {
+			return 0, fmt.Errorf("error recording migration %d: %v", version+1, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("error committing migrations: %v", err)
+	}
+
+	return version, nil
+}
+
+// AppliedSchemaVersion returns the schema version recorded in the database.
+func AppliedSchemaVersion(ctx context.Context) (int, error) {
+	db, err := connectToDatabase()
+	if err != nil {
+		return 0, fmt.Errorf("error connecting to database: %v", err)
+	}
+
+	ctx, span := startSpan(ctx, "SELECT", "schema_migrations")
+	var version int
+	err = db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
+	endSpan(span, 1, err)
+	if err != nil {
+		return 0, fmt.Errorf("error reading schema version: %v", err)
+	}
+
+	return version, nil
+}
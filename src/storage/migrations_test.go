@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		mock := mockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(migrationLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT COALESCE\\(MAX\\(version\\), 0\\) FROM schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(SchemaVersion() - 1))
+		for range migrations[SchemaVersion()-1] {
+			mock.ExpectExec(".").WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(SchemaVersion()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// Act
+		version, err := Migrate()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, SchemaVersion(), version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("interactive-presentation/src/storage")
+
+// startSpan starts a span for a query running operation, e.g. SELECT, on
+// table.
+func startSpan(ctx context.Context, operation string, table string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, operation+" "+table,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", operation),
+			attribute.String("db.sql.table", table),
+		),
+	)
+}
+
+// endSpan records the number of rows the query returned or affected and
+// whether it failed.
+func endSpan(span trace.Span, rows int64, err error) {
+	span.SetAttributes(attribute.Int64("db.rows", rows))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func rowsAffected(result sql.Result) int64 {
+	if result == nil {
+		return 0
+	}
+	rows, _ := result.RowsAffected()
+	return rows
+}
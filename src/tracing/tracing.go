@@ -0,0 +1,106 @@
+// Package tracing sets up OpenTelemetry tracing of HTTP requests, storage
+// queries and upstream calls, propagating the W3C trace context.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporters that spans can be sent to.
+const (
+	// ExporterNone records no spans, trace context is still propagated.
+	ExporterNone = "none"
+	// ExporterOTLP sends spans over OTLP/HTTP to the collector configured with
+	// the standard OTEL_EXPORTER_OTLP_* environment variables.
+	ExporterOTLP = "otlp"
+	// ExporterStdout writes spans as JSON lines to stdout.
+	ExporterStdout = "stdout"
+)
+
+const serviceName = "interactive-presentation"
+
+// ValidExporter reports whether exporter is one of the supported exporters.
+func ValidExporter(exporter string) bool {
+	return exporter == ExporterNone || exporter == ExporterOTLP || exporter == ExporterStdout
+}
+
+// Setup installs the global W3C trace context propagator and a tracer
+// provider sending spans to exporter. The returned function flushes pending
+// spans and stops the provider.
+func Setup(ctx context.Context, exporter string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		spanExporter, err = otlptracehttp.New(ctx)
+	case ExporterStdout:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter: %s", exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s trace exporter: %v", exporter, err)
+	}
+
+	// Attributes from OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES take
+	// precedence over the default service name.
+	serviceResource, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", serviceName)),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating trace resource: %v", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(serviceResource),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Middleware starts a server span for every request, continuing the trace of
+// the caller if it sent a traceparent header. Spans are named after the chi
+// route pattern once the request has been routed.
+func Middleware(next http.Handler) http.Handler {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		if routeContext := chi.RouteContext(r.Context()); routeContext != nil && routeContext.RoutePattern() != "" {
+			span := trace.SpanFromContext(r.Context())
+			span.SetName(r.Method + " " + routeContext.RoutePattern())
+			span.SetAttributes(attribute.String("http.route", routeContext.RoutePattern()))
+		}
+	})
+	return otelhttp.NewHandler(handler, "http.request", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return r.Method
+	}))
+}
+
+// Transport wraps next to start a client span for every upstream call and to
+// pass the trace context on in its headers.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(next, otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return "upstream " + r.Method + " " + r.URL.Path
+	}))
+}
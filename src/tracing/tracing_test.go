@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMiddleware(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	r := chi.NewRouter()
+	r.Use(Middleware)
+	r.Get("/presentations/{presentation_id}", func(http.ResponseWriter, *http.Request) {})
+	request := httptest.NewRequest(http.MethodGet, "/presentations/1", nil)
+	request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	// Act
+	r.ServeHTTP(httptest.NewRecorder(), request)
+
+	// Assert
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "GET /presentations/{presentation_id}", spans[0].Name())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	}
+}
+
+func TestSetup(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Act
+		shutdown, err := Setup(context.Background(), ExporterStdout)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, shutdown(context.Background()))
+	})
+
+	t.Run("Unknown Exporter", func(t *testing.T) {
+		// Act
+		_, err := Setup(context.Background(), "zipkin")
+
+		// Assert
+		assert.EqualError(t, err, "unknown trace exporter: zipkin")
+	})
+}
@@ -0,0 +1,93 @@
+package utilities
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"interactive-presentation/src/logging"
+)
+
+// ProblemContentType is the media type of error responses.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details object. Code is a stable identifier
+// of the kind of error that clients can rely on, while Title and Detail are
+// meant for humans and may change.
+type Problem struct {
+	Type      string      `json:"type"`
+	Title     string      `json:"title"`
+	Status    int         `json:"status"`
+	Code      string      `json:"code"`
+	Detail    string      `json:"detail,omitempty"`
+	Instance  string      `json:"instance,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+	Errors    interface{} `json:"errors,omitempty"`
+}
+
+func NewProblem(status int, code string, title string) Problem {
+	return Problem{Type: "/problems/" + code, Title: title, Status: status, Code: code}
+}
+
+// WithDetail returns a copy of the problem explaining this occurrence of it.
+func (p Problem) WithDetail(format string, args ...interface{}) Problem {
+	p.Detail = fmt.Sprintf(format, args...)
+	return p
+}
+
+// WithErrors returns a copy of the problem listing the individual errors
+// behind it, e.g. one per invalid field.
+func (p Problem) WithErrors(errors interface{}) Problem {
+	p.Errors = errors
+	return p
+}
+
+var (
+	ProblemInvalidPresentationID  = NewProblem(http.StatusBadRequest, "invalid_presentation_id", "Invalid presentation ID")
+	ProblemInvalidPollID          = NewProblem(http.StatusBadRequest, "invalid_poll_id", "Invalid poll ID")
+	ProblemInvalidSubmissionID    = NewProblem(http.StatusBadRequest, "invalid_submission_id", "Invalid submission ID")
+	ProblemInvalidRequestBody     = NewProblem(http.StatusBadRequest, "invalid_request_body", "Invalid request body")
+	ProblemInvalidParameter       = NewProblem(http.StatusBadRequest, "invalid_parameter", "Invalid query parameter")
+	ProblemInvalidPresentation    = NewProblem(http.StatusBadRequest, "invalid_presentation", "Invalid presentation")
+	ProblemValidationFailed       = NewProblem(http.StatusBadRequest, "validation_failed", "Request body failed validation")
+	ProblemHostNotAllowed         = NewProblem(http.StatusBadRequest, "host_not_allowed", "Host is not allowed in join links")
+	ProblemPresenterTokenRequired = NewProblem(http.StatusUnauthorized, "presenter_token_required", "Presenter token required")
+	ProblemInvalidPresenterToken  = NewProblem(http.StatusForbidden, "invalid_presenter_token", "Invalid presenter token")
+	ProblemPresentationNotFound   = NewProblem(http.StatusNotFound, "presentation_not_found", "No presentation found")
+	ProblemPollNotFound           = NewProblem(http.StatusNotFound, "poll_not_found", "No poll found")
+	ProblemSubmissionNotFound     = NewProblem(http.StatusNotFound, "submission_not_found", "No submission found")
+	ProblemJoinCodeNotFound       = NewProblem(http.StatusNotFound, "join_code_not_found", "No join code found")
+	ProblemInvalidIdempotencyKey  = NewProblem(http.StatusBadRequest, "invalid_idempotency_key", "Invalid idempotency key")
+	ProblemPollClosed             = NewProblem(http.StatusConflict, "poll_closed", "Poll is closed")
+	ProblemPollNotCurrent         = NewProblem(http.StatusConflict, "poll_not_current", "Poll is not the current poll")
+	ProblemIdempotencyInProgress  = NewProblem(http.StatusConflict, "idempotency_key_in_progress", "Request with this idempotency key is in progress")
+	ProblemIdempotencyKeyReused   = NewProblem(http.StatusUnprocessableEntity, "idempotency_key_reused", "Idempotency key was used for a different request")
+	ProblemRateLimited            = NewProblem(http.StatusTooManyRequests, "rate_limited", "Too many requests")
+	ProblemInternal               = NewProblem(http.StatusInternalServerError, "internal_error", "Internal server error")
+	ProblemUpstreamFailed         = NewProblem(http.StatusBadGateway, "upstream_failed", "Presentation service failed")
+)
+
+// WriteProblem answers the request with p. The cause err is logged with the
+// request's context and never sent to the client.
+func WriteProblem(w http.ResponseWriter, r *http.Request, p Problem, err error) {
+	p.Instance = r.URL.Path
+	p.RequestID = logging.RequestIDFromContext(r.Context())
+
+	level := slog.LevelWarn
+	if p.Status >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	attrs := []interface{}{"status", p.Status, "code", p.Code}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+	slog.Log(r.Context(), level, p.Title, attrs...)
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	if err = json.NewEncoder(w).Encode(p); err != nil {
+		slog.ErrorContext(r.Context(), "error writing problem", "error", err)
+	}
+}
@@ -1,11 +1,16 @@
 package utilities
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
+	"strings"
+	"unicode"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -17,14 +22,17 @@ func ParseUUIDFromRequest(r *http.Request, paramName string) (uuid.UUID, error)
 }
 
 func WriteJSONResponse(w http.ResponseWriter, data interface{}) error {
-	w.Header().Set("Content-Type", "application/json")
-	err := json.NewEncoder(w).Encode(data)
+	body, err := json.Marshal(data)
 	if err != nil {
-		log.Println(err)
-		http.Error(w, "Failed to encode JSON", http.StatusInternalServerError)
+		slog.Error("error encoding JSON response", "error", err)
+		w.Header().Set("Content-Type", ProblemContentType)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(ProblemInternal)
 		return err
 	}
-	return nil
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(append(body, '\n'))
+	return err
 }
 
 func ReadRequestBody(r *http.Request) ([]byte, error) {
@@ -41,3 +49,49 @@ func ReadRequestBody(r *http.Request) ([]byte, error) {
 	}(r.Body)
 	return bodyBytes, bodyCloseError
 }
+
+// joinCodeAlphabet leaves out characters that are easily confused when read
+// from a projector, such as 0/O and 1/I/L.
+const joinCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+func GenerateJoinCode(length int) (string, error) {
+	randomBytes := make([]byte, length)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", fmt.Errorf("failed to generate join code: %v", err)
+	}
+	code := make([]byte, length)
+	for i, randomByte := range randomBytes {
+		// 248 is the largest multiple of the alphabet length below 256, skipping
+		// bytes above it keeps every character equally likely.
+		for randomByte >= 248 {
+			if _, err := rand.Read(randomBytes[i : i+1]); err != nil {
+				return "", fmt.Errorf("failed to generate join code: %v", err)
+			}
+			randomByte = randomBytes[i]
+		}
+		code[i] = joinCodeAlphabet[int(randomByte)%len(joinCodeAlphabet)]
+	}
+	return string(code), nil
+}
+
+func NormalizeJoinCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return unicode.ToUpper(r)
+	}, code)
+}
+
+func GenerateToken() (string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", fmt.Errorf("failed to generate token: %v", err)
+	}
+	return hex.EncodeToString(tokenBytes), nil
+}
+
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
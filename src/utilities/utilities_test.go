@@ -13,6 +13,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"interactive-presentation/src/logging"
 )
 
 func TestParseUUIDFromRequest(t *testing.T) {
@@ -88,6 +90,50 @@ func TestWriteJSONResponse(t *testing.T) {
 	})
 }
 
+func TestWriteProblem(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/presentations/abc", nil)
+		r.Header.Set(logging.RequestIDHeader, "request-1")
+		handler := logging.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			WriteProblem(w, r, ProblemPollNotFound.WithDetail("Poll %d does not exist", 3), nil)
+		}))
+
+		// Act
+		handler.ServeHTTP(w, r)
+
+		// Assert
+		var problem Problem
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, Problem{
+			Type:      "/problems/poll_not_found",
+			Title:     "No poll found",
+			Status:    http.StatusNotFound,
+			Code:      "poll_not_found",
+			Detail:    "Poll 3 does not exist",
+			Instance:  "/presentations/abc",
+			RequestID: "request-1",
+		}, problem)
+	})
+
+	t.Run("Internal Error", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/presentations", nil)
+
+		// Act
+		WriteProblem(w, r, ProblemInternal, fmt.Errorf("error selecting from poll table: pq: password authentication failed"))
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"internal_error"`)
+		assert.NotContains(t, w.Body.String(), "pq:")
+	})
+}
+
 func TestReadRequestBody(t *testing.T) {
 	t.Run("Success Case", func(t *testing.T) {
 		// Arrange
@@ -117,6 +163,68 @@ func TestReadRequestBody(t *testing.T) {
 	})
 }
 
+func TestGenerateJoinCode(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Act
+		code, err := GenerateJoinCode(6)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, code, 6)
+		for _, r := range code {
+			assert.Contains(t, joinCodeAlphabet, string(r))
+		}
+	})
+
+	t.Run("Ambiguous Characters Are Never Used", func(t *testing.T) {
+		// Act
+		code, err := GenerateJoinCode(512)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotContains(t, code, "0")
+		assert.NotContains(t, code, "O")
+		assert.NotContains(t, code, "1")
+		assert.NotContains(t, code, "I")
+		assert.NotContains(t, code, "L")
+	})
+}
+
+func TestNormalizeJoinCode(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Act
+		code := NormalizeJoinCode(" ab3-x7k ")
+
+		// Assert
+		assert.Equal(t, "AB3X7K", code)
+	})
+}
+
+func TestGenerateToken(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Act
+		first, errFirst := GenerateToken()
+		second, errSecond := GenerateToken()
+
+		// Assert
+		assert.NoError(t, errFirst)
+		assert.NoError(t, errSecond)
+		assert.Len(t, first, 64)
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestHashToken(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Act
+		hash := HashToken("secret")
+
+		// Assert
+		assert.Equal(t, "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b", hash)
+		assert.NotEqual(t, hash, HashToken("Secret"))
+	})
+}
+
 type readError struct{}
 
 func (r *readError) Read(_ []byte) (int, error) {
@@ -0,0 +1,176 @@
+// Package validation checks request bodies against rules declared in
+// `validate` struct tags and reports every violation together with the JSON
+// path of the field it concerns.
+//
+// Rules are separated by commas:
+//
+//	required  strings must not be blank, other values must not be empty
+//	min=N     strings need at least N characters, slices N elements
+//	max=N     strings may have at most N characters, slices N elements
+//	unique=F  the structs in a slice differ in their field F
+//
+// Nested structs and slices of structs are validated recursively.
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	var messages []string
+	for _, fieldError := range e {
+		messages = append(messages, fmt.Sprintf("%s: %s", fieldError.Field, fieldError.Message))
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Validate checks v, a struct or a pointer to one. When any rule is violated
+// the returned error is of type Errors.
+func Validate(v interface{}) error {
+	var errs Errors
+	validateStruct(reflect.Indirect(reflect.ValueOf(v)), "", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func validateStruct(value reflect.Value, path string, errs *Errors) {
+	structType := value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, ok := fieldName(field)
+		if !ok {
+			continue
+		}
+		fieldPath := joinPath(path, name)
+		fieldValue := value.Field(i)
+
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			if rule == "" {
+				continue
+			}
+			applyRule(rule, fieldValue, fieldPath, errs)
+		}
+
+		switch fieldValue.Kind() {
+		case reflect.Struct:
+			validateStruct(fieldValue, fieldPath, errs)
+		case reflect.Slice:
+			if fieldValue.Type().Elem().Kind() == reflect.Struct {
+				for j := 0; j < fieldValue.Len(); j++ {
+					validateStruct(fieldValue.Index(j), fmt.Sprintf("%s[%d]", fieldPath, j), errs)
+				}
+			}
+		}
+	}
+}
+
+func applyRule(rule string, value reflect.Value, path string, errs *Errors) {
+	name, argument, _ := strings.Cut(rule, "=")
+	switch name {
+	case "required":
+		if isEmpty(value) {
+			*errs = append(*errs, FieldError{Field: path, Message: "is required"})
+		}
+	case "min":
+		limit := intArgument(rule, argument)
+		if length, unit := measure(value); length < limit {
+			*errs = append(*errs, FieldError{Field: path, Message: fmt.Sprintf("must have at least %d %s", limit, unit)})
+		}
+	case "max":
+		limit := intArgument(rule, argument)
+		if length, unit := measure(value); length > limit {
+			*errs = append(*errs, FieldError{Field: path, Message: fmt.Sprintf("must have at most %d %s", limit, unit)})
+		}
+	case "unique":
+		checkUnique(value, argument, path, errs)
+	default:
+		panic(fmt.Sprintf("validation: unknown rule %q", rule))
+	}
+}
+
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(value.String()) == ""
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	default:
+		return value.IsZero()
+	}
+}
+
+// measure returns the length min and max compare against, characters for
+// strings and elements for everything else.
+func measure(value reflect.Value) (int, string) {
+	if value.Kind() == reflect.String {
+		return utf8.RuneCountInString(value.String()), "characters"
+	}
+	return value.Len(), "elements"
+}
+
+func checkUnique(value reflect.Value, keyField string, path string, errs *Errors) {
+	field, ok := value.Type().Elem().FieldByName(keyField)
+	if !ok {
+		panic(fmt.Sprintf("validation: %s has no field %s", value.Type().Elem(), keyField))
+	}
+	name, _ := fieldName(field)
+
+	seen := make(map[interface{}]string)
+	for i := 0; i < value.Len(); i++ {
+		keyValue := value.Index(i).FieldByIndex(field.Index)
+		if isEmpty(keyValue) {
+			continue
+		}
+		key := keyValue.Interface()
+		elementPath := fmt.Sprintf("%s[%d].%s", path, i, name)
+		if firstPath, found := seen[key]; found {
+			*errs = append(*errs, FieldError{Field: elementPath, Message: fmt.Sprintf("duplicates %s", firstPath)})
+			continue
+		}
+		seen[key] = elementPath
+	}
+}
+
+func intArgument(rule string, argument string) int {
+	limit, err := strconv.Atoi(argument)
+	if err != nil {
+		panic(fmt.Sprintf("validation: rule %q needs a number", rule))
+	}
+	return limit
+}
+
+// fieldName returns the name a field has in JSON, reporting false for fields
+// that are not encoded.
+func fieldName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
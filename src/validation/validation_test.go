@@ -0,0 +1,77 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"interactive-presentation/src/models"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		presentation := models.Presentation{Polls: []models.Poll{{
+			Question: "What's your favorite pet?",
+			Options:  []models.Option{{Key: "A", Value: "Dog"}, {Key: "B", Value: "Cat"}},
+		}}}
+
+		// Act
+		err := Validate(&presentation)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Invalid Presentation", func(t *testing.T) {
+		// Arrange
+		presentation := models.Presentation{Polls: []models.Poll{
+			{
+				Question: " ",
+				Options:  []models.Option{{Key: "A", Value: "Dog"}},
+			},
+			{
+				Question: strings.Repeat("?", 256),
+				Options:  []models.Option{{Key: "A", Value: "Dog"}, {Key: "", Value: "Cat"}, {Key: "A", Value: "Fish"}},
+			},
+		}}
+
+		// Act
+		err := Validate(presentation)
+
+		// Assert
+		var errs Errors
+		assert.True(t, errors.As(err, &errs))
+		assert.Equal(t, Errors{
+			{Field: "polls[0].question", Message: "is required"},
+			{Field: "polls[0].options", Message: "must have at least 2 elements"},
+			{Field: "polls[1].question", Message: "must have at most 255 characters"},
+			{Field: "polls[1].options[2].key", Message: "duplicates polls[1].options[0].key"},
+			{Field: "polls[1].options[1].key", Message: "is required"},
+		}, errs)
+	})
+
+	t.Run("Too Many Polls", func(t *testing.T) {
+		// Arrange
+		presentation := models.Presentation{Polls: make([]models.Poll, 101)}
+
+		// Act
+		err := Validate(presentation)
+
+		// Assert
+		var errs Errors
+		assert.True(t, errors.As(err, &errs))
+		assert.Equal(t, FieldError{Field: "polls", Message: "must have at most 100 elements"}, errs[0])
+	})
+
+	t.Run("Invalid Vote", func(t *testing.T) {
+		// Act
+		err := Validate(models.Vote{PollID: uuid.New()})
+
+		// Assert
+		assert.EqualError(t, err, "key: is required\nclient_id: is required")
+	})
+}
@@ -0,0 +1,110 @@
+"use strict";
+
+const refreshInterval = 2000;
+
+const state = {
+  presentationID: null,
+  poll: null,
+  clientID: localStorage.getItem("client_id"),
+};
+
+if (!state.clientID) {
+  state.clientID = crypto.randomUUID();
+  localStorage.setItem("client_id", state.clientID);
+}
+
+function votedKey(pollID) {
+  return "vote:" + pollID;
+}
+
+async function join(code) {
+  try {
+    const joinCode = await api("GET", "/v2/join/" + encodeURIComponent(code.trim()));
+    state.presentationID = joinCode.presentation_id;
+    history.replaceState(null, "", "/?code=" + encodeURIComponent(joinCode.code));
+    document.getElementById("join").classList.add("hidden");
+    document.getElementById("poll").classList.remove("hidden");
+    document.getElementById("reactions").classList.remove("hidden");
+    showMessage();
+    await refresh();
+    setInterval(refresh, refreshInterval);
+  } catch (error) {
+    showMessage(error.status === 404 ? "Unknown or expired join code" : error.message);
+  }
+}
+
+async function refresh() {
+  const base = "/v2/presentations/" + state.presentationID;
+  try {
+    const poll = await api("GET", base + "/polls/current");
+    if (!state.poll || state.poll.poll_id !== poll.poll_id) {
+      state.poll = poll;
+      renderPoll();
+    }
+    if (localStorage.getItem(votedKey(poll.poll_id))) {
+      renderResults(document.getElementById("results"), await api("GET", base + "/polls/" + poll.poll_id + "/results"));
+    }
+  } catch (error) {
+    showMessage(error.message);
+  }
+}
+
+function renderPoll() {
+  const poll = state.poll;
+  const voted = localStorage.getItem(votedKey(poll.poll_id));
+  document.getElementById("question").textContent = poll.question || "Waiting for the next poll…";
+  document.getElementById("results").replaceChildren();
+
+  const options = document.getElementById("options");
+  options.replaceChildren();
+  for (const option of poll.options || []) {
+    const button = document.createElement("button");
+    button.type = "button";
+    button.textContent = option.value;
+    button.disabled = Boolean(voted);
+    if (voted === option.key) button.classList.add("selected");
+    button.addEventListener("click", () => vote(option.key));
+    options.append(button);
+  }
+}
+
+async function vote(key) {
+  const poll = state.poll;
+  try {
+    await api("POST", "/v2/presentations/" + state.presentationID + "/polls/current/votes", {
+      key,
+      client_id: state.clientID,
+      poll_id: poll.poll_id,
+    });
+    localStorage.setItem(votedKey(poll.poll_id), key);
+    showMessage();
+  } catch (error) {
+    showMessage(error.status === 409 ? "This poll is closed" : error.message);
+    return;
+  }
+  renderPoll();
+  await refresh();
+}
+
+async function react(emoji) {
+  try {
+    await api("POST", "/v2/presentations/" + state.presentationID + "/reactions", { client_id: state.clientID, emoji });
+  } catch (error) {
+    if (error.status !== 429) showMessage(error.message);
+  }
+}
+
+document.getElementById("join-form").addEventListener("submit", (event) => {
+  event.preventDefault();
+  join(document.getElementById("code").value);
+});
+
+for (const button of document.querySelectorAll("#reactions button")) {
+  button.addEventListener("click", () => react(button.dataset.emoji));
+}
+
+const code = new URLSearchParams(location.search).get("code");
+if (code) {
+  document.getElementById("code").value = code;
+  join(code);
+}
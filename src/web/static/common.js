@@ -0,0 +1,45 @@
+"use strict";
+
+async function api(method, path, body, token) {
+  const headers = { "Accept": "application/json" };
+  if (body !== undefined) headers["Content-Type"] = "application/json";
+  if (token) headers["Authorization"] = "Bearer " + token;
+
+  const response = await fetch(path, { method, headers, body: body === undefined ? undefined : JSON.stringify(body) });
+  if (!response.ok) {
+    const text = (await response.text()).trim();
+    let message = text || response.statusText;
+    let code;
+    if ((response.headers.get("Content-Type") || "").startsWith("application/problem+json")) {
+      const problem = JSON.parse(text);
+      message = problem.detail || problem.title;
+      code = problem.code;
+    }
+    const error = new Error(message);
+    error.status = response.status;
+    error.code = code;
+    throw error;
+  }
+  if (response.status === 204) return null;
+  return response.json();
+}
+
+function renderResults(element, results) {
+  element.replaceChildren();
+  for (const option of results.options) {
+    const percent = results.votes ? Math.round(option.votes * 100 / results.votes) : 0;
+    const row = document.createElement("div");
+    row.className = "result";
+    const label = document.createElement("div");
+    label.textContent = `${option.value} — ${option.votes} (${percent}%)`;
+    const bar = document.createElement("div");
+    bar.className = "bar";
+    bar.style.width = percent + "%";
+    row.append(label, bar);
+    element.append(row);
+  }
+}
+
+function showMessage(text) {
+  document.getElementById("message").textContent = text || "";
+}
@@ -0,0 +1,109 @@
+"use strict";
+
+const refreshInterval = 1000;
+
+const state = {
+  presentationID: null,
+  token: null,
+  status: null,
+  reactions: {},
+};
+
+function base() {
+  return "/v2/presentations/" + state.presentationID;
+}
+
+async function open(presentationID, token) {
+  state.presentationID = presentationID.trim();
+  state.token = token.trim();
+  try {
+    await refresh();
+  } catch (error) {
+    showMessage(error.message);
+    return;
+  }
+  sessionStorage.setItem("presentation_id", state.presentationID);
+  sessionStorage.setItem("presenter_token", state.token);
+  document.getElementById("login").classList.add("hidden");
+  document.getElementById("session").classList.remove("hidden");
+  showMessage();
+
+  await loadJoinCode();
+  listenForReactions();
+  setInterval(() => refresh().catch((error) => showMessage(error.message)), refreshInterval);
+}
+
+async function loadJoinCode() {
+  try {
+    const joinCode = await api("GET", base() + "/join-code");
+    document.getElementById("join-code").textContent = joinCode.code;
+    document.getElementById("join-url").textContent = location.origin + "/join/" + joinCode.code;
+    document.getElementById("qr").src = base() + "/qr.svg";
+  } catch (error) {
+    showMessage(error.message);
+  }
+}
+
+async function refresh() {
+  const status = await api("GET", base() + "/polls/current/status");
+  state.status = status;
+
+  document.getElementById("previous").disabled = status.index <= 0;
+  document.getElementById("next").disabled = status.index >= status.polls - 1;
+  document.getElementById("toggle").textContent = status.status === "open" ? "Close poll" : "Open poll";
+  document.getElementById("status").textContent = `Poll ${status.index + 1} of ${status.polls} — ${status.status}`;
+
+  if (status.index >= status.polls) {
+    document.getElementById("question").textContent = "No more polls";
+    document.getElementById("results").replaceChildren();
+    return;
+  }
+  const results = await api("GET", base() + "/polls/" + status.poll_id + "/results");
+  document.getElementById("question").textContent = results.question;
+  renderResults(document.getElementById("results"), results);
+}
+
+async function show(index) {
+  try {
+    await api("PUT", base() + "/polls/current", { index }, state.token);
+    await refresh();
+  } catch (error) {
+    showMessage(error.message);
+  }
+}
+
+async function toggle() {
+  const status = state.status.status === "open" ? "closed" : "open";
+  try {
+    await api("PUT", base() + "/polls/current/status", { status }, state.token);
+    await refresh();
+  } catch (error) {
+    showMessage(error.message);
+  }
+}
+
+function listenForReactions() {
+  const source = new EventSource(base() + "/reactions/stream");
+  source.addEventListener("reactions", (event) => {
+    const counts = JSON.parse(event.data).counts || {};
+    for (const [emoji, count] of Object.entries(counts)) {
+      state.reactions[emoji] = (state.reactions[emoji] || 0) + count;
+    }
+    document.getElementById("reaction-counts").textContent =
+      Object.entries(state.reactions).map(([emoji, count]) => `${emoji} ${count}`).join("   ");
+  });
+}
+
+document.getElementById("login-form").addEventListener("submit", (event) => {
+  event.preventDefault();
+  open(document.getElementById("presentation").value, document.getElementById("token").value);
+});
+document.getElementById("previous").addEventListener("click", () => show(state.status.index - 1));
+document.getElementById("next").addEventListener("click", () => show(state.status.index + 1));
+document.getElementById("toggle").addEventListener("click", toggle);
+
+const params = new URLSearchParams(location.search);
+const presentationID = params.get("presentation") || sessionStorage.getItem("presentation_id");
+const token = sessionStorage.getItem("presenter_token");
+if (presentationID) document.getElementById("presentation").value = presentationID;
+if (presentationID && token) open(presentationID, token);
@@ -0,0 +1,34 @@
+// Package web embeds the audience and presenter pages so the service can
+// serve them without a separate frontend deployment.
+package web
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed static
+var static embed.FS
+
+var assets, _ = fs.Sub(static, "static")
+
+// Assets serves the embedded stylesheets and scripts. It is meant to be
+// mounted with the "/assets/" prefix stripped.
+func Assets() http.Handler {
+	return http.FileServer(http.FS(assets))
+}
+
+// Page returns a handler writing the embedded HTML page with the given name.
+func Page(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, err := fs.ReadFile(assets, name)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		_, _ = w.Write(page)
+	}
+}
@@ -0,0 +1,50 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPage(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		recorder := httptest.NewRecorder()
+
+		// Act
+		Page("index.html")(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+		assert.Contains(t, recorder.Body.String(), "/assets/audience.js")
+	})
+
+	t.Run("Unknown Page", func(t *testing.T) {
+		// Arrange
+		recorder := httptest.NewRecorder()
+
+		// Act
+		Page("missing.html")(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
+func TestAssets(t *testing.T) {
+	// Arrange
+	handler := http.StripPrefix("/assets/", Assets())
+
+	for _, name := range []string{"app.css", "common.js", "audience.js", "presenter.js"} {
+		recorder := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/assets/"+name, nil))
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorder.Code, name)
+	}
+}
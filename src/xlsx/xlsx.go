@@ -0,0 +1,227 @@
+// Package xlsx writes minimal Office Open XML workbooks with inline string
+// and number cells, enough for spreadsheet exports without a third party
+// dependency.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxSheetNameLength = 31
+)
+
+type Workbook struct {
+	sheets []*Sheet
+}
+
+type Sheet struct {
+	name string
+	rows []row
+}
+
+type row struct {
+	bold   bool
+	values []interface{}
+}
+
+func New() *Workbook {
+	return &Workbook{}
+}
+
+// AddSheet appends a sheet to the workbook. Characters Excel does not allow in
+// sheet names are replaced, the name is truncated to 31 characters and made
+// unique within the workbook.
+func (wb *Workbook) AddSheet(name string) *Sheet {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if name == "" {
+		name = "Sheet"
+	}
+
+	unique := truncate(name, maxSheetNameLength)
+	for i := 2; wb.hasSheet(unique); i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		unique = truncate(name, maxSheetNameLength-len(suffix)) + suffix
+	}
+
+	sheet := &Sheet{name: unique}
+	wb.sheets = append(wb.sheets, sheet)
+	return sheet
+}
+
+// AddHeader appends a row rendered in bold.
+func (s *Sheet) AddHeader(values ...interface{}) {
+	s.rows = append(s.rows, row{bold: true, values: values})
+}
+
+// AddRow appends a row. Integers and floats are written as numbers, every
+// other value as text.
+func (s *Sheet) AddRow(values ...interface{}) {
+	s.rows = append(s.rows, row{values: values})
+}
+
+func (wb *Workbook) Write(w io.Writer) error {
+	if len(wb.sheets) == 0 {
+		wb.AddSheet("Sheet1")
+	}
+
+	archive := zip.NewWriter(w)
+	parts := []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{"[Content_Types].xml", wb.writeContentTypes},
+		{"_rels/.rels", writeRootRels},
+		{"xl/workbook.xml", wb.writeWorkbook},
+		{"xl/_rels/workbook.xml.rels", wb.writeWorkbookRels},
+		{"xl/styles.xml", writeStyles},
+	}
+	for i, sheet := range wb.sheets {
+		sheet := sheet
+		parts = append(parts, struct {
+			name  string
+			write func(io.Writer) error
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.write})
+	}
+
+	for _, part := range parts {
+		partWriter, err := archive.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("error creating %s: %v", part.name, err)
+		}
+		if _, err = io.WriteString(partWriter, xml.Header); err != nil {
+			return err
+		}
+		if err = part.write(partWriter); err != nil {
+			return fmt.Errorf("error writing %s: %v", part.name, err)
+		}
+	}
+
+	return archive.Close()
+}
+
+func (wb *Workbook) hasSheet(name string) bool {
+	for _, sheet := range wb.sheets {
+		if strings.EqualFold(sheet.name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (wb *Workbook) writeContentTypes(w io.Writer) error {
+	var overrides strings.Builder
+	for i := range wb.sheets {
+		_, _ = fmt.Fprintf(&overrides, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+	}
+	_, err := fmt.Fprintf(w, `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`+
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`+
+		`<Default Extension="xml" ContentType="application/xml"/>`+
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`+
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`+
+		`%s</Types>`, overrides.String())
+	return err
+}
+
+func writeRootRels(w io.Writer) error {
+	_, err := io.WriteString(w, `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`+
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>`+
+		`</Relationships>`)
+	return err
+}
+
+func (wb *Workbook) writeWorkbook(w io.Writer) error {
+	var sheets strings.Builder
+	for i, sheet := range wb.sheets {
+		_, _ = fmt.Fprintf(&sheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(sheet.name), i+1, i+1)
+	}
+	_, err := fmt.Fprintf(w, `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`+
+		`<sheets>%s</sheets></workbook>`, sheets.String())
+	return err
+}
+
+func (wb *Workbook) writeWorkbookRels(w io.Writer) error {
+	var relationships strings.Builder
+	for i := range wb.sheets {
+		_, _ = fmt.Fprintf(&relationships, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	_, err := fmt.Fprintf(w, `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`+
+		`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`+
+		`%s</Relationships>`, len(wb.sheets)+1, relationships.String())
+	return err
+}
+
+// writeStyles declares two cell formats, the default one and a bold one used
+// for header rows.
+func writeStyles(w io.Writer) error {
+	_, err := io.WriteString(w, `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`+
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>`+
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>`+
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>`+
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>`+
+		`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>`+
+		`</styleSheet>`)
+	return err
+}
+
+func (s *Sheet) write(w io.Writer) error {
+	if _, err := io.WriteString(w, `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return err
+	}
+	for i, row := range s.rows {
+		style := ""
+		if row.bold {
+			style = ` s="1"`
+		}
+		var cells strings.Builder
+		for j, value := range row.values {
+			reference := ColumnName(j) + strconv.Itoa(i+1)
+			switch v := value.(type) {
+			case int, int32, int64, uint, uint32, uint64, float32, float64:
+				_, _ = fmt.Fprintf(&cells, `<c r="%s"%s><v>%v</v></c>`, reference, style, v)
+			default:
+				_, _ = fmt.Fprintf(&cells, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, reference, style, escape(fmt.Sprint(v)))
+			}
+		}
+		if _, err := fmt.Fprintf(w, `<row r="%d">%s</row>`, i+1, cells.String()); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, `</sheetData></worksheet>`)
+	return err
+}
+
+// ColumnName converts a zero based column index to its spreadsheet name, so
+// 0 is A, 25 is Z and 26 is AA.
+func ColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+func escape(value string) string {
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(value))
+	return escaped.String()
+}
+
+func truncate(value string, length int) string {
+	runes := []rune(value)
+	if len(runes) > length {
+		return string(runes[:length])
+	}
+	return value
+}
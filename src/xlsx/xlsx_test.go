@@ -0,0 +1,71 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColumnName(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Assert
+		assert.Equal(t, "A", ColumnName(0))
+		assert.Equal(t, "Z", ColumnName(25))
+		assert.Equal(t, "AA", ColumnName(26))
+		assert.Equal(t, "AZ", ColumnName(51))
+		assert.Equal(t, "BA", ColumnName(52))
+	})
+}
+
+func TestWorkbookAddSheet(t *testing.T) {
+	t.Run("Invalid Characters And Duplicates", func(t *testing.T) {
+		// Arrange
+		wb := New()
+
+		// Act
+		first := wb.AddSheet("Q1: What/Why?")
+		second := wb.AddSheet("q1: what/why?")
+		long := wb.AddSheet("A very long sheet name that does not fit")
+
+		// Assert
+		assert.Equal(t, "Q1_ What_Why_", first.name)
+		assert.Equal(t, "q1_ what_why_ (2)", second.name)
+		assert.Len(t, []rune(long.name), 31)
+	})
+}
+
+func TestWorkbookWrite(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		// Arrange
+		wb := New()
+		sheet := wb.AddSheet("Summary")
+		sheet.AddHeader("Question", "Votes")
+		sheet.AddRow("Cats & dogs?", 3)
+
+		// Act
+		var buffer bytes.Buffer
+		err := wb.Write(&buffer)
+
+		// Assert
+		assert.NoError(t, err)
+		archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		assert.NoError(t, err)
+
+		files := make(map[string]string)
+		for _, file := range archive.File {
+			reader, err := file.Open()
+			assert.NoError(t, err)
+			content, err := io.ReadAll(reader)
+			assert.NoError(t, err)
+			files[file.Name] = string(content)
+		}
+		assert.Contains(t, files, "[Content_Types].xml")
+		assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Summary" sheetId="1" r:id="rId1"/>`)
+		assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Question</t></is></c>`)
+		assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<t xml:space="preserve">Cats &amp; dogs?</t>`)
+		assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<c r="B2"><v>3</v></c>`)
+	})
+}